@@ -0,0 +1,118 @@
+package expressiontree
+
+// ahoCorasick is automaton for search of many literals in one pass over value.
+// Search is byte-based: for valid UTF-8 byte substring is rune substring too.
+type ahoCorasick struct {
+	nodes    []ahoCorasickNode
+	literals []string
+}
+
+type ahoCorasickNode struct {
+	next map[byte]int32
+	fail int32
+	// indices of literals ended in this node (including literals from fail chain)
+	outputs []int
+}
+
+const ahoCorasickRoot int32 = 0
+
+func createAhoCorasick(literals []string) *ahoCorasick {
+	automaton := &ahoCorasick{nodes: []ahoCorasickNode{{next: map[byte]int32{}}}, literals: literals}
+	for index, literal := range literals {
+		automaton.addLiteral(index, literal)
+	}
+	automaton.buildFailLinks()
+	return automaton
+}
+
+func (a *ahoCorasick) addLiteral(index int, literal string) {
+	current := ahoCorasickRoot
+	for position := 0; position < len(literal); position++ {
+		next, exists := a.nodes[current].next[literal[position]]
+		if !exists {
+			next = int32(len(a.nodes))
+			a.nodes = append(a.nodes, ahoCorasickNode{next: map[byte]int32{}})
+			a.nodes[current].next[literal[position]] = next
+		}
+		current = next
+	}
+	a.nodes[current].outputs = append(a.nodes[current].outputs, index)
+}
+
+// breadth-first traversal: fail link of node is longest proper suffix which is prefix of some literal
+func (a *ahoCorasick) buildFailLinks() {
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[ahoCorasickRoot].next {
+		a.nodes[child].fail = ahoCorasickRoot
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for symbol, child := range a.nodes[current].next {
+			fail := a.nodes[current].fail
+			for {
+				if next, exists := a.nodes[fail].next[symbol]; exists {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == ahoCorasickRoot {
+					a.nodes[child].fail = ahoCorasickRoot
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+			failOutputs := a.nodes[a.nodes[child].fail].outputs
+			a.nodes[child].outputs = append(a.nodes[child].outputs, failOutputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (a *ahoCorasick) step(current int32, symbol byte) int32 {
+	for {
+		if next, exists := a.nodes[current].next[symbol]; exists {
+			return next
+		}
+		if current == ahoCorasickRoot {
+			return ahoCorasickRoot
+		}
+		current = a.nodes[current].fail
+	}
+}
+
+func (a *ahoCorasick) containsAny(value string) bool {
+	if len(a.nodes[ahoCorasickRoot].outputs) > 0 {
+		// empty literal
+		return true
+	}
+	current := ahoCorasickRoot
+	for position := 0; position < len(value); position++ {
+		current = a.step(current, value[position])
+		if len(a.nodes[current].outputs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findAll returns indices of found literals in order of end of their first occurrence
+func (a *ahoCorasick) findAll(value string) []int {
+	found := make([]int, 0)
+	seen := make(map[int]struct{})
+	collect := func(outputs []int) {
+		for _, index := range outputs {
+			if _, exists := seen[index]; !exists {
+				seen[index] = struct{}{}
+				found = append(found, index)
+			}
+		}
+	}
+	collect(a.nodes[ahoCorasickRoot].outputs)
+	current := ahoCorasickRoot
+	for position := 0; position < len(value); position++ {
+		current = a.step(current, value[position])
+		collect(a.nodes[current].outputs)
+	}
+	return found
+}
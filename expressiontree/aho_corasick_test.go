@@ -0,0 +1,122 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAhoCorasickFindAll(t *testing.T) {
+	automaton := createAhoCorasick([]string{"he", "she", "his", "hers", "сон"})
+	assert.Equal(t, []int{1, 0, 3}, automaton.findAll("ushers"))
+	assert.Equal(t, []int{2}, automaton.findAll("this"))
+	assert.Equal(t, []int{4}, automaton.findAll("бессонница"))
+	assert.Empty(t, automaton.findAll("abc"))
+	assert.True(t, automaton.containsAny("ahishers"))
+	assert.False(t, automaton.containsAny("hs"))
+}
+
+func TestAhoCorasickRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	randomString := func(maxLength int) string {
+		builder := &strings.Builder{}
+		length := 1 + random.Intn(maxLength)
+		for index := 0; index < length; index++ {
+			builder.WriteByte(byte('a' + random.Intn(3)))
+		}
+		return builder.String()
+	}
+	for iteration := 0; iteration < 200; iteration++ {
+		literals := make([]string, 1+random.Intn(10))
+		for index := range literals {
+			literals[index] = randomString(4)
+		}
+		automaton := createAhoCorasick(literals)
+		value := randomString(30)
+		found := automaton.findAll(value)
+		expectedAny := false
+		for index, literal := range literals {
+			contains := strings.Contains(value, literal)
+			expectedAny = expectedAny || contains
+			assert.Equal(t, contains, containsIndex(found, index), "%v in %q", literals, value)
+		}
+		assert.Equal(t, expectedAny, automaton.containsAny(value))
+	}
+}
+
+func containsIndex(indices []int, index int) bool {
+	for _, current := range indices {
+		if current == index {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMultiLiteralPattern(t *testing.T) {
+	registry := CreatePatternRegistry()
+	assert.NoError(t, registry.Add(PatternDefinition{
+		Id:         1,
+		Kind:       MultiLiteralPattern,
+		Values:     []string{"sqlmap", "Nikto", "masscan", "SQLMap"},
+		IgnoreCase: true,
+	}))
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 2, Kind: ExactPattern, Value: "a"}))
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 3, Kind: MultiLiteralPattern}), badPatternError)
+	matchResult, matchError := registry.MatchPattern(1, "Mozilla/5.0 nikto/2.1")
+	assert.NoError(t, matchError)
+	assert.True(t, matchResult)
+	matchResult, found, findError := registry.MatchPatternLiterals(1, "SQLMAP via masscan")
+	assert.NoError(t, findError)
+	assert.True(t, matchResult)
+	assert.Equal(t, []string{"sqlmap", "masscan"}, found)
+	matchResult, found, findError = registry.MatchPatternLiterals(1, "curl")
+	assert.NoError(t, findError)
+	assert.False(t, matchResult)
+	assert.Empty(t, found)
+	matchResult, found, findError = registry.MatchPatternLiterals(2, "a")
+	assert.NoError(t, findError)
+	assert.True(t, matchResult)
+	assert.Nil(t, found)
+	_, _, unknownError := registry.MatchPatternLiterals(4, "a")
+	assert.Equal(t, unknownPatternError, unknownError)
+	expression, expressionError := ParseRuleWithOptions("MATCH(http.request.headers.User-Agent, 1)", CompileOptions{Patterns: registry})
+	assert.NoError(t, expressionError)
+	data := &HttpData{Request: RequestData{Headers: map[string][]string{"User-Agent": {"curl", "sqlmap/1.7 masscan", "nikto"}}}}
+	manager := CreateExecutionManager(registry)
+	actualResult, actualError := expression(context.Background(), data, manager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+	// literals are reported by MATCH for the first matched value
+	recorded := map[uint][]string{}
+	recordingManager := manager.WithLiteralRecorder(func(patternId uint, literals []string) {
+		recorded[patternId] = append(recorded[patternId], literals...)
+	})
+	actualResult, actualError = expression(context.Background(), data, recordingManager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+	assert.Equal(t, map[uint][]string{1: {"sqlmap", "masscan"}}, recorded)
+	assert.Nil(t, manager.recordLiterals)
+}
+
+func BenchmarkMultiLiteralPattern(b *testing.B) {
+	literals := make([]string, 5000)
+	for index := range literals {
+		literals[index] = fmt.Sprintf("bad-agent-%d/", index)
+	}
+	registry := CreatePatternRegistry()
+	if addError := registry.Add(PatternDefinition{Id: 1, Kind: MultiLiteralPattern, Values: literals}); addError != nil {
+		b.Fatal(addError)
+	}
+	value := strings.Repeat("Mozilla/5.0 (X11; Linux x86_64) ", 8) + "bad-agent-4999/"
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		if result, _ := registry.MatchPattern(1, value); !result {
+			b.Fatal("literal isn't found")
+		}
+	}
+}
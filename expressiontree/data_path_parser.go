@@ -0,0 +1,197 @@
+package expressiontree
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Data path syntax: MAIN_PATH(.CONTENT_PART)*
+// MAIN_PATH: one of names from dataKeyNames, e.g. http.request.headers
+// CONTENT_PART: name without dots/quotes/spaces/delimiters/comment starts (// and /*) or double-quoted name, e.g. "X.Forwarded"
+// e.g. http.request.headers.Content-Type, http.request.body.items."a.b".name
+// ContentPath.Path is raw (unquoted) content parts joined by dots, so for simple content path it is the name,
+// content parts are quoted only by String()
+
+var badPathError = errors.New("bad path")
+
+type pathSegment struct {
+	value  string
+	quoted bool
+}
+
+// ParseDataPath parses dotted path into main path and content path
+func ParseDataPath(source string) (DataPath, error) {
+	segments, segmentsError := splitPathSegments(source)
+	if segmentsError != nil {
+		return DataPath{}, segmentsError
+	}
+	mainLength, mainPath := findMainPath(segments)
+	if mainLength == 0 {
+		return DataPath{}, unknownMainPathError
+	}
+	contentParts := make([]string, 0, len(segments)-mainLength)
+	for _, segment := range segments[mainLength:] {
+		contentParts = append(contentParts, segment.value)
+	}
+	path := CreateDataPath(mainPath, CreateContentPath(strings.Join(contentParts, "."), contentParts))
+	if validateError := validateContentPath(path); validateError != nil {
+		return DataPath{}, validateError
+	}
+	return path, nil
+}
+
+func (p DataPath) String() string {
+	if p.ContentPath.IsEmpty() {
+		return p.MainPath.String()
+	}
+	return p.MainPath.String() + "." + formatContentParts(p.ContentPath.Parts)
+}
+
+func (p DataPath) MarshalText() ([]byte, error) {
+	if _, exists := dataKeyNames[p.MainPath]; !exists {
+		return nil, unknownMainPathError
+	}
+	return []byte(p.String()), nil
+}
+
+func (p *DataPath) UnmarshalText(text []byte) error {
+	path, pathError := ParseDataPath(string(text))
+	if pathError != nil {
+		return pathError
+	}
+	*p = path
+	return nil
+}
+
+func (key TDataKey) String() string {
+	name, exists := dataKeyNames[key]
+	if !exists {
+		return "TDataKey(" + strconv.Itoa(int(key)) + ")"
+	}
+	return name
+}
+
+// CanHaveContentPath returns true if content path is allowed after main path
+func (key TDataKey) CanHaveContentPath() bool {
+	switch key {
+	case OptionsKey, RequestPathsKey, RequestBodyKey, RequestGetKey, RequestPostKey, RequestHeadersKey,
+		RequestCookiesKey, ResponseBodyKey, ResponseHeadersKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateContentPath(path DataPath) error {
+	switch {
+	case path.ContentPath.IsEmpty():
+		return nil
+	case !path.MainPath.CanHaveContentPath():
+		return badContentPathError
+	case path.MainPath == OptionsKey && !path.ContentPath.IsSimple():
+		return badContentPathError
+	case path.MainPath == RequestPathsKey && !path.ContentPath.IsSimple():
+		return badContentPathError
+	case path.MainPath == RequestPathsKey:
+		index, convertError := strconv.Atoi(path.ContentPath.Parts[0])
+		if convertError != nil || index < 0 {
+			return badRequestPathIndexError
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func splitPathSegments(source string) ([]pathSegment, error) {
+	segments := make([]pathSegment, 0)
+	rest := source
+	for {
+		var segment pathSegment
+		if strings.HasPrefix(rest, `"`) {
+			end := findClosingQuote(rest)
+			if end == -1 {
+				return nil, badPathError
+			}
+			value, unquoteError := strconv.Unquote(rest[0 : end+1])
+			if unquoteError != nil {
+				return nil, badPathError
+			}
+			segment = pathSegment{value: value, quoted: true}
+			rest = rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, `."`)
+			if end == -1 {
+				end = len(rest)
+			}
+			segment = pathSegment{value: rest[0:end], quoted: false}
+			rest = rest[end:]
+		}
+		if !segment.quoted && (len(segment.value) == 0 || strings.ContainsFunc(segment.value, isPathSpecialRune)) {
+			return nil, badPathError
+		}
+		segments = append(segments, segment)
+		if len(rest) == 0 {
+			return segments, nil
+		}
+		if rest[0] != '.' {
+			return nil, badPathError
+		}
+		rest = rest[1:]
+	}
+}
+
+func findClosingQuote(source string) int {
+	for index := 1; index < len(source); index++ {
+		switch source[index] {
+		case '\\':
+			index++
+		case '"':
+			return index
+		}
+	}
+	return -1
+}
+
+// longest prefix of unquoted segments which is known main path
+func findMainPath(segments []pathSegment) (int, TDataKey) {
+	names := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment.quoted {
+			break
+		}
+		names = append(names, segment.value)
+	}
+	for mainLength := len(names); mainLength > 0; mainLength-- {
+		if mainPath, exists := dataKeysByName[strings.Join(names[0:mainLength], ".")]; exists {
+			return mainLength, mainPath
+		}
+	}
+	return 0, 0
+}
+
+func formatContentParts(parts []string) string {
+	builder := &strings.Builder{}
+	for index, part := range parts {
+		if index > 0 {
+			builder.WriteRune('.')
+		}
+		if len(part) == 0 || strings.ContainsFunc(part, isPathSpecialRune) || containsCommentStart(part) {
+			builder.WriteString(strconv.Quote(part))
+		} else {
+			builder.WriteString(part)
+		}
+	}
+	return builder.String()
+}
+
+// containsCommentStart returns true if unquoted part would be split by comment of rule language
+func containsCommentStart(part string) bool {
+	return strings.Contains(part, lineCommentStart) || strings.Contains(part, blockCommentStart)
+}
+
+func isPathSpecialRune(char rune) bool {
+	return char == '.' || unicode.IsSpace(char) || strings.ContainsRune(ruleDelimiters, char)
+}
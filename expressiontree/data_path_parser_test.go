@@ -0,0 +1,139 @@
+package expressiontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDataPath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		expectedPath  DataPath
+		expectedError error
+	}{
+		{
+			name:          "main path only",
+			source:        "http.client.geoip.country_code",
+			expectedPath:  CreateDataPathWithMainOnly(GeoIpCountryCodeKey),
+			expectedError: nil,
+		},
+		{
+			name:          "simple content path",
+			source:        "http.request.headers.Content-Type",
+			expectedPath:  CreateDataPathWithSimpleContent(RequestHeadersKey, "Content-Type"),
+			expectedError: nil,
+		},
+		{
+			name:   "content path with several parts",
+			source: "http.request.body.items.0.name",
+			expectedPath: CreateDataPath(RequestBodyKey,
+				CreateContentPath("items.0.name", []string{"items", "0", "name"})),
+			expectedError: nil,
+		},
+		{
+			name:   "quoted content part",
+			source: `http.request.get."a.b".c`,
+			expectedPath: CreateDataPath(RequestGetKey,
+				CreateContentPath("a.b.c", []string{"a.b", "c"})),
+			expectedError: nil,
+		},
+		{
+			name:          "quoted content part with escape",
+			source:        `http.request.cookies."x\"y"`,
+			expectedPath:  CreateDataPath(RequestCookiesKey, CreateContentPath(`x"y`, []string{`x"y`})),
+			expectedError: nil,
+		},
+		{
+			name:          "request paths index",
+			source:        "http.request.paths.2",
+			expectedPath:  CreateDataPathWithSimpleContent(RequestPathsKey, "2"),
+			expectedError: nil,
+		},
+		{
+			name:          "quoted main path",
+			source:        `http."request".headers`,
+			expectedError: badContentPathError,
+		},
+		{
+			name:          "content path not allowed",
+			source:        "http.client.geoip.country_code.x",
+			expectedError: badContentPathError,
+		},
+		{
+			name:          "complex option",
+			source:        "http.options.a.b",
+			expectedError: badContentPathError,
+		},
+		{
+			name:          "bad request paths index",
+			source:        "http.request.paths.first",
+			expectedError: badRequestPathIndexError,
+		},
+		{
+			name:          "negative request paths index",
+			source:        "http.request.paths.-1",
+			expectedError: badRequestPathIndexError,
+		},
+		{
+			name:          "unknown main path",
+			source:        "https.request",
+			expectedError: unknownMainPathError,
+		},
+		{
+			name:          "empty",
+			source:        "",
+			expectedError: badPathError,
+		},
+		{
+			name:          "trailing dot",
+			source:        "http.request.headers.",
+			expectedError: badPathError,
+		},
+		{
+			name:          "unterminated quote",
+			source:        `http.request.headers."abc`,
+			expectedError: badPathError,
+		},
+		{
+			name:          "symbols after quote",
+			source:        `http.request.headers."abc"def`,
+			expectedError: badPathError,
+		},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			actualPath, actualError := ParseDataPath(currentTestCase.source)
+			if currentTestCase.expectedError == nil {
+				assert.NoError(t, actualError)
+				assert.Equal(t, currentTestCase.expectedPath, actualPath)
+				assert.Equal(t, currentTestCase.source, actualPath.String())
+			} else {
+				assert.Equal(t, currentTestCase.expectedError, actualError)
+			}
+		})
+	}
+}
+
+func TestDataPathRoundTrip(t *testing.T) {
+	for key := range dataKeyNames {
+		paths := []DataPath{CreateDataPathWithMainOnly(key)}
+		if key.CanHaveContentPath() {
+			paths = append(paths, CreateDataPathWithSimpleContent(key, "1"))
+		}
+		if key.CanHaveContentPath() && key != OptionsKey && key != RequestPathsKey {
+			paths = append(paths, CreateDataPath(key, CreateContentPath("", []string{"x.y", "", "z w", "a//b", "c/*d", "e/f", "q"})))
+		}
+		for _, path := range paths {
+			text, textError := path.MarshalText()
+			assert.NoError(t, textError)
+			actualPath := DataPath{}
+			assert.NoError(t, actualPath.UnmarshalText(text))
+			assert.Equal(t, path.MainPath, actualPath.MainPath)
+			assert.Equal(t, path.ContentPath.Parts, actualPath.ContentPath.Parts)
+			assert.Equal(t, string(text), actualPath.String())
+		}
+	}
+}
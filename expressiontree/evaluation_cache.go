@@ -0,0 +1,126 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// EvaluationCache memoizes results of nodes for one evaluation of one HttpData.
+// Key of node is its canonical source (kind, path, pattern id or operation and argument of leaf),
+// so equal leaves and subexpressions of all predicates evaluated with the cache call manager once.
+// Cache is bound to first evaluated HttpData, other HttpData are evaluated without cache.
+// Errors of done context aren't cached. EvaluationCache is safe for concurrent use.
+type EvaluationCache struct {
+	mutex   sync.Mutex
+	data    *HttpData
+	results map[string]cachedResult
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+type cachedResult struct {
+	result bool
+	err    error
+}
+
+type evaluationCacheKey struct{}
+
+func CreateEvaluationCache() *EvaluationCache {
+	return &EvaluationCache{results: map[string]cachedResult{}}
+}
+
+// WithEvaluationCache returns context for evaluation with cache
+func WithEvaluationCache(ctx context.Context, cache *EvaluationCache) context.Context {
+	return context.WithValue(ctx, evaluationCacheKey{}, cache)
+}
+
+// EvaluationCacheFromContext returns cache of evaluation or nil
+func EvaluationCacheFromContext(ctx context.Context) *EvaluationCache {
+	cache, _ := ctx.Value(evaluationCacheKey{}).(*EvaluationCache)
+	return cache
+}
+
+func (c *EvaluationCache) Hits() uint64 {
+	return c.hits.Load()
+}
+
+func (c *EvaluationCache) Misses() uint64 {
+	return c.misses.Load()
+}
+
+func (c *EvaluationCache) lookup(key string, data *HttpData) (cachedResult, bool, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.data == nil {
+		c.data = data
+	}
+	if c.data != data {
+		return cachedResult{}, false, false
+	}
+	result, exists := c.results[key]
+	return result, exists, true
+}
+
+func (c *EvaluationCache) store(key string, data *HttpData, result cachedResult) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.data == data {
+		c.results[key] = result
+	}
+}
+
+// load returns memoized result of key, miss is counted only if result can be stored for data
+func (c *EvaluationCache) load(key string, data *HttpData) (cachedResult, bool) {
+	if c == nil {
+		return cachedResult{}, false
+	}
+	cached, exists, cacheable := c.lookup(key, data)
+	switch {
+	case !cacheable:
+		return cachedResult{}, false
+	case exists:
+		c.hits.Add(1)
+		return cached, true
+	default:
+		c.misses.Add(1)
+		return cachedResult{}, false
+	}
+}
+
+// save stores result of key unless evaluation was stopped by done context
+func (c *EvaluationCache) save(ctx context.Context, key string, data *HttpData, result bool, err error) {
+	if c == nil || (err != nil && evaluationContextError(ctx) != nil) {
+		return
+	}
+	c.store(key, data, cachedResult{result: result, err: err})
+}
+
+// createCached memoizes predicate of node if evaluation context has cache
+func createCached(node Node, predicate PredicateWithError, options CompileOptions) PredicateWithError {
+	key := cacheKey(node, options)
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		return EvaluationCacheFromContext(ctx).evaluate(ctx, key, predicate, data, manager)
+	}
+}
+
+// cacheKey is canonical source of node, results of logical nodes depend on evaluation mode, so mode is part of their key
+func cacheKey(node Node, options CompileOptions) string {
+	key := node.String()
+	switch node.(type) {
+	case *AndNode, *OrNode, *NotNode:
+		key = fmt.Sprintf("%d:%v", options.Mode, key)
+	}
+	return key
+}
+
+// evaluate returns memoized result of predicate, predicate is evaluated without cache if c is nil
+func (c *EvaluationCache) evaluate(ctx context.Context, key string, predicate PredicateWithError, data *HttpData, manager IExecutionManager) (bool, error) {
+	if cached, exists := c.load(key, data); exists {
+		return cached.result, cached.err
+	}
+	result, err := predicate(ctx, data, manager)
+	c.save(ctx, key, data, result, err)
+	return result, err
+}
@@ -0,0 +1,110 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationCache(t *testing.T) {
+	httpData := &HttpData{}
+	first, firstError := ParseRule("AND(EXISTS(http.options.A), MATCH(http.options.B, 7))")
+	assert.NoError(t, firstError)
+	second, secondError := ParseRule("OR(NOT(EXISTS(http.options.A)), MATCH(http.options.B, 7))")
+	assert.NoError(t, secondError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(true, nil).Times(1)
+	executionManager.EXPECT().MatchOption(gomock.Any(), uint(7), "B", httpData).Return(true, nil).Times(1)
+	cache := CreateEvaluationCache()
+	ctx := WithEvaluationCache(context.Background(), cache)
+	firstResult, firstEvaluationError := first(ctx, httpData, executionManager)
+	assert.NoError(t, firstEvaluationError)
+	assert.True(t, firstResult)
+	secondResult, secondEvaluationError := second(ctx, httpData, executionManager)
+	assert.NoError(t, secondEvaluationError)
+	assert.True(t, secondResult)
+	// AND, EXISTS, MATCH, OR, NOT are evaluated, EXISTS and MATCH are reused
+	assert.Equal(t, uint64(5), cache.Misses())
+	assert.Equal(t, uint64(2), cache.Hits())
+	// repeated evaluation is taken from cache
+	firstResult, firstEvaluationError = first(ctx, httpData, executionManager)
+	assert.NoError(t, firstEvaluationError)
+	assert.True(t, firstResult)
+	assert.Equal(t, uint64(3), cache.Hits())
+}
+
+func TestEvaluationCacheBounds(t *testing.T) {
+	someError := errors.New("some error")
+	httpData := &HttpData{}
+	otherHttpData := &HttpData{Host: "example.com"}
+	expression, expressionError := ParseRule("EXISTS(http.options.A)")
+	assert.NoError(t, expressionError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	// errors are cached, other HttpData isn't cached, evaluation without cache calls manager
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(false, someError).Times(2)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", otherHttpData).Return(true, nil).Times(2)
+	cache := CreateEvaluationCache()
+	ctx := WithEvaluationCache(context.Background(), cache)
+	for iteration := 0; iteration < 2; iteration++ {
+		_, actualError := expression(ctx, httpData, executionManager)
+		assert.Equal(t, someError, actualError)
+		actualResult, otherError := expression(ctx, otherHttpData, executionManager)
+		assert.NoError(t, otherError)
+		assert.True(t, actualResult)
+	}
+	_, actualError := expression(context.Background(), httpData, executionManager)
+	assert.Equal(t, someError, actualError)
+	assert.Equal(t, uint64(1), cache.Misses())
+	assert.Equal(t, uint64(1), cache.Hits())
+}
+
+func TestEvaluationCacheContextError(t *testing.T) {
+	httpData := &HttpData{}
+	expression, expressionError := ParseRule("EXISTS(http.options.A)")
+	assert.NoError(t, expressionError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	cache := CreateEvaluationCache()
+	ctx, cancel := context.WithCancel(WithEvaluationCache(context.Background(), cache))
+	gomock.InOrder(
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).DoAndReturn(
+			func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+				cancel()
+				return false, ctx.Err()
+			}),
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(true, nil),
+	)
+	_, actualError := expression(ctx, httpData, executionManager)
+	assert.ErrorIs(t, actualError, context.Canceled)
+	actualResult, actualError := expression(WithEvaluationCache(context.Background(), cache), httpData, executionManager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+}
+
+func TestRuleSetSharedSubexpressions(t *testing.T) {
+	httpData := &HttpData{}
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "a", Source: "AND(OR(EXISTS(http.options.A), EXISTS(http.options.B)), EXISTS(http.options.C))"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "b", Source: "AND(OR(EXISTS(http.options.A), EXISTS(http.options.B)), NOT(EXISTS(http.options.C)))"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "c", Source: "OR(EXISTS(http.options.A), EXISTS(http.options.B))"}))
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(false, nil).Times(1)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "B", httpData).Return(true, nil).Times(1)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "C", httpData).Return(true, nil).Times(1)
+	cache := CreateEvaluationCache()
+	matched, evaluateError := ruleSet.Evaluate(WithEvaluationCache(context.Background(), cache), httpData, executionManager)
+	assert.NoError(t, evaluateError)
+	assert.Len(t, matched, 2)
+	// OR is shared by all rules, EXISTS(C) by first and second rules
+	assert.Equal(t, uint64(3), cache.Hits())
+}
@@ -0,0 +1,44 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var evaluationTimeoutError = errors.New("evaluation timeout")
+var evaluationCanceledError = errors.New("evaluation canceled")
+
+// evaluationContextError returns nil if evaluation can be continued,
+// returned error wraps both evaluation error and context error (context.DeadlineExceeded or context.Canceled)
+func evaluationContextError(ctx context.Context) error {
+	contextError := ctx.Err()
+	switch {
+	case contextError == nil:
+		return nil
+	case errors.Is(contextError, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", evaluationTimeoutError, contextError)
+	default:
+		return fmt.Errorf("%w: %w", evaluationCanceledError, contextError)
+	}
+}
+
+// createEvaluation sets deadline for each evaluation of predicate if timeout is positive,
+// errors of evaluation stopped by done context (e.g. ctx.Err() returned by manager) are replaced with evaluation error
+func createEvaluation(predicate PredicateWithError, timeout time.Duration) PredicateWithError {
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		if timeout > 0 {
+			timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			ctx = timeoutCtx
+		}
+		result, err := predicate(ctx, data, manager)
+		if err != nil {
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+		}
+		return result, err
+	}
+}
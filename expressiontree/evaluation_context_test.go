@@ -0,0 +1,109 @@
+package expressiontree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationCancellation(t *testing.T) {
+	httpData := &HttpData{}
+	testCases := []struct {
+		name string
+		mode EvaluationMode
+		// if true, A returns context error, otherwise A is true and cancellation is noticed before B
+		failA bool
+	}{
+		{name: "strict", mode: StrictErrors},
+		{name: "strict, failed leaf", mode: StrictErrors, failA: true},
+		{name: "error as false", mode: ErrorAsFalse},
+		{name: "error as false, failed leaf", mode: ErrorAsFalse, failA: true},
+		{name: "kleene", mode: KleeneLogic},
+		{name: "kleene, failed leaf", mode: KleeneLogic, failA: true},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			expression, expressionError := ParseRuleWithOptions(
+				"AND(EXISTS(http.options.A), OR(EXISTS(http.options.B), EXISTS(http.options.C)))",
+				CompileOptions{Mode: currentTestCase.mode})
+			assert.NoError(t, expressionError)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+			executionManager := NewMockIExecutionManager(mockController)
+			// B and C must not be called
+			executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).DoAndReturn(
+				func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+					cancel()
+					if currentTestCase.failA {
+						return false, ctx.Err()
+					}
+					return true, nil
+				})
+			actualResult, actualError := expression(ctx, httpData, executionManager)
+			assert.False(t, actualResult)
+			assert.ErrorIs(t, actualError, evaluationCanceledError)
+			assert.ErrorIs(t, actualError, context.Canceled)
+		})
+	}
+}
+
+func TestEvaluationTimeout(t *testing.T) {
+	httpData := &HttpData{}
+	expression, expressionError := ParseRuleWithOptions(
+		"AND(MATCH(http.request.body, 1), EXISTS(http.options.A))",
+		CompileOptions{Timeout: 10 * time.Millisecond})
+	assert.NoError(t, expressionError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	executionManager.EXPECT().RecursiveMatchRequestBody(gomock.Any(), uint(1), gomock.Any(), httpData).DoAndReturn(
+		func(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+			// slow match notices deadline
+			<-ctx.Done()
+			return true, nil
+		})
+	actualResult, actualError := expression(context.Background(), httpData, executionManager)
+	assert.False(t, actualResult)
+	assert.ErrorIs(t, actualError, evaluationTimeoutError)
+	assert.ErrorIs(t, actualError, context.DeadlineExceeded)
+	assert.Equal(t, "evaluation timeout: context deadline exceeded", actualError.Error())
+}
+
+func TestEvaluationCanceledBeforeCheck(t *testing.T) {
+	expression, expressionError := ParseRule(`CHECK(http.host == "example.com")`)
+	assert.NoError(t, expressionError)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	actualResult, actualError := expression(ctx, createSampleHttpData(), CreateExecutionManager(nil))
+	assert.False(t, actualResult)
+	assert.ErrorIs(t, actualError, context.Canceled)
+}
+
+func TestExplainCancellation(t *testing.T) {
+	httpData := &HttpData{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).DoAndReturn(
+		func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+			cancel()
+			return true, nil
+		})
+	node, nodeError := ParseRuleNode("AND(EXISTS(http.options.A), EXISTS(http.options.B))")
+	assert.NoError(t, nodeError)
+	explanation, explainError := Explain(ctx, node, httpData, executionManager)
+	assert.NoError(t, explainError)
+	assert.ErrorIs(t, explanation.Err, context.Canceled)
+	expectedText := "AND -> error: evaluation canceled: context canceled\n" +
+		"  EXISTS(http.options.A) -> true\n" +
+		"  EXISTS(http.options.B) -> skipped\n"
+	assert.Equal(t, expectedText, explanation.String())
+}
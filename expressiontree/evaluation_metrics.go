@@ -0,0 +1,288 @@
+package expressiontree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const metricsPrefix = "expressiontree_"
+
+// upper bounds of latency histogram buckets in seconds
+var latencyBuckets = []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// EvaluationMetrics records counters and latency histograms of rules (see Instrument) and of manager calls
+// by main path of leaf (leaves are instrumented by CompileOptions.Metrics). Metrics are exposed through expvar
+// (EvaluationMetrics implements expvar.Var, e.g. expvar.Publish("rules", metrics)) and in Prometheus text format
+// (EvaluationMetrics implements http.Handler). EvaluationMetrics is safe for concurrent use.
+type EvaluationMetrics struct {
+	mutex    sync.RWMutex
+	rules    map[string]*ruleMetrics
+	dataKeys map[TDataKey]*callMetrics
+}
+
+type ruleMetrics struct {
+	matches atomic.Uint64
+	callMetrics
+}
+
+type callMetrics struct {
+	calls   atomic.Uint64
+	errors  atomic.Uint64
+	latency latencyHistogram
+}
+
+type latencyHistogram struct {
+	// counts[i] is count of observations in (latencyBuckets[i-1], latencyBuckets[i]], last count is for +Inf
+	counts   [12]atomic.Uint64
+	sumNanos atomic.Uint64
+}
+
+type jsonCallMetrics struct {
+	Calls   uint64            `json:"calls"`
+	Matches *uint64           `json:"matches,omitempty"`
+	Errors  uint64            `json:"errors"`
+	Latency jsonLatencyMetric `json:"latency"`
+}
+
+type jsonLatencyMetric struct {
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+	Buckets map[string]uint64 `json:"buckets"`
+}
+
+func CreateEvaluationMetrics() *EvaluationMetrics {
+	return &EvaluationMetrics{rules: map[string]*ruleMetrics{}, dataKeys: map[TDataKey]*callMetrics{}}
+}
+
+// Instrument records evaluations, matches, errors and latency of predicate as metrics of rule
+func (m *EvaluationMetrics) Instrument(rule string, predicate PredicateWithError) PredicateWithError {
+	metrics := m.rule(rule)
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		start := time.Now()
+		result, err := predicate(ctx, data, manager)
+		metrics.record(time.Since(start), err)
+		if result && err == nil {
+			metrics.matches.Add(1)
+		}
+		return result, err
+	}
+}
+
+// instrumentLeaf records manager calls of leaf by its main path, m may be nil
+func (m *EvaluationMetrics) instrumentLeaf(node Node, leaf PredicateWithError) PredicateWithError {
+	if m == nil {
+		return leaf
+	}
+	var key TDataKey
+	switch current := node.(type) {
+	case *CheckNode:
+		key = current.Path.MainPath
+	case *ExistsNode:
+		key = current.Path.MainPath
+	case *MatchNode:
+		key = current.Path.MainPath
+	default:
+		return leaf
+	}
+	metrics := m.dataKey(key)
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		start := time.Now()
+		result, err := leaf(ctx, data, manager)
+		metrics.record(time.Since(start), err)
+		return result, err
+	}
+}
+
+func (m *EvaluationMetrics) rule(rule string) *ruleMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	metrics, exists := m.rules[rule]
+	if !exists {
+		metrics = &ruleMetrics{}
+		m.rules[rule] = metrics
+	}
+	return metrics
+}
+
+func (m *EvaluationMetrics) dataKey(key TDataKey) *callMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	metrics, exists := m.dataKeys[key]
+	if !exists {
+		metrics = &callMetrics{}
+		m.dataKeys[key] = metrics
+	}
+	return metrics
+}
+
+func (c *callMetrics) record(duration time.Duration, err error) {
+	c.calls.Add(1)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	c.latency.observe(duration)
+}
+
+func (h *latencyHistogram) observe(duration time.Duration) {
+	seconds := duration.Seconds()
+	bucket := sort.SearchFloat64s(latencyBuckets, seconds)
+	h.counts[bucket].Add(1)
+	h.sumNanos.Add(uint64(max(duration, 0)))
+}
+
+// cumulative returns cumulative counts of buckets (last is count of all observations) and sum in seconds
+func (h *latencyHistogram) cumulative() ([]uint64, float64) {
+	counts := make([]uint64, len(h.counts))
+	total := uint64(0)
+	for index := range h.counts {
+		total += h.counts[index].Load()
+		counts[index] = total
+	}
+	return counts, time.Duration(h.sumNanos.Load()).Seconds()
+}
+
+// sortedRules returns names and metrics of rules sorted by name
+func (m *EvaluationMetrics) sortedRules() ([]string, []*ruleMetrics) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	names := make([]string, 0, len(m.rules))
+	for name := range m.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := make([]*ruleMetrics, 0, len(names))
+	for _, name := range names {
+		metrics = append(metrics, m.rules[name])
+	}
+	return names, metrics
+}
+
+// sortedDataKeys returns names and metrics of main paths sorted by name
+func (m *EvaluationMetrics) sortedDataKeys() ([]string, []*callMetrics) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	keys := make([]TDataKey, 0, len(m.dataKeys))
+	for key := range m.dataKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(left int, right int) bool { return keys[left].String() < keys[right].String() })
+	names := make([]string, 0, len(keys))
+	metrics := make([]*callMetrics, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.String())
+		metrics = append(metrics, m.dataKeys[key])
+	}
+	return names, metrics
+}
+
+// String returns metrics as JSON object (it implements expvar.Var):
+// {"rules": {"id": {"calls", "matches", "errors", "latency"}}, "manager": {"http.request.body": {"calls", "errors", "latency"}}}
+func (m *EvaluationMetrics) String() string {
+	rules := map[string]jsonCallMetrics{}
+	ruleNames, ruleValues := m.sortedRules()
+	for index, name := range ruleNames {
+		metric := ruleValues[index].callMetrics.toJson()
+		matches := ruleValues[index].matches.Load()
+		metric.Matches = &matches
+		rules[name] = metric
+	}
+	manager := map[string]jsonCallMetrics{}
+	keyNames, keyValues := m.sortedDataKeys()
+	for index, name := range keyNames {
+		manager[name] = keyValues[index].toJson()
+	}
+	result, _ := json.Marshal(map[string]map[string]jsonCallMetrics{"rules": rules, "manager": manager})
+	return string(result)
+}
+
+func (c *callMetrics) toJson() jsonCallMetrics {
+	counts, sum := c.latency.cumulative()
+	buckets := make(map[string]uint64, len(counts))
+	for index, count := range counts {
+		buckets[bucketBound(index)] = count
+	}
+	return jsonCallMetrics{
+		Calls:   c.calls.Load(),
+		Errors:  c.errors.Load(),
+		Latency: jsonLatencyMetric{Count: counts[len(counts)-1], Sum: sum, Buckets: buckets},
+	}
+}
+
+func bucketBound(index int) string {
+	if index == len(latencyBuckets) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(latencyBuckets[index], 'g', -1, 64)
+}
+
+// WritePrometheus writes metrics in Prometheus text exposition format
+func (m *EvaluationMetrics) WritePrometheus(writer io.Writer) error {
+	builder := &strings.Builder{}
+	ruleNames, ruleValues := m.sortedRules()
+	ruleCalls := make([]*callMetrics, 0, len(ruleValues))
+	for _, metrics := range ruleValues {
+		ruleCalls = append(ruleCalls, &metrics.callMetrics)
+	}
+	writeCounter(builder, "rule_evaluations_total", "Count of rule evaluations.", "rule", ruleNames,
+		func(index int) uint64 { return ruleCalls[index].calls.Load() })
+	writeCounter(builder, "rule_matches_total", "Count of rule evaluations with true result.", "rule", ruleNames,
+		func(index int) uint64 { return ruleValues[index].matches.Load() })
+	writeCounter(builder, "rule_errors_total", "Count of failed rule evaluations.", "rule", ruleNames,
+		func(index int) uint64 { return ruleCalls[index].errors.Load() })
+	writeHistogram(builder, "rule_duration_seconds", "Latency of rule evaluations.", "rule", ruleNames, ruleCalls)
+	keyNames, keyValues := m.sortedDataKeys()
+	writeCounter(builder, "manager_calls_total", "Count of manager calls by main path.", "path", keyNames,
+		func(index int) uint64 { return keyValues[index].calls.Load() })
+	writeCounter(builder, "manager_errors_total", "Count of failed manager calls by main path.", "path", keyNames,
+		func(index int) uint64 { return keyValues[index].errors.Load() })
+	writeHistogram(builder, "manager_call_duration_seconds", "Latency of manager calls by main path.", "path", keyNames, keyValues)
+	_, writeError := io.WriteString(writer, builder.String())
+	return writeError
+}
+
+func writeCounter(builder *strings.Builder, name string, help string, label string, labelValues []string, value func(index int) uint64) {
+	if len(labelValues) == 0 {
+		return
+	}
+	fmt.Fprintf(builder, "# HELP %s%s %s\n# TYPE %s%s counter\n", metricsPrefix, name, help, metricsPrefix, name)
+	for index, labelValue := range labelValues {
+		fmt.Fprintf(builder, "%s%s{%s=\"%s\"} %d\n", metricsPrefix, name, label, escapeLabelValue(labelValue), value(index))
+	}
+}
+
+func writeHistogram(builder *strings.Builder, name string, help string, label string, labelValues []string, metrics []*callMetrics) {
+	if len(labelValues) == 0 {
+		return
+	}
+	fmt.Fprintf(builder, "# HELP %s%s %s\n# TYPE %s%s histogram\n", metricsPrefix, name, help, metricsPrefix, name)
+	for index, labelValue := range labelValues {
+		labelPair := fmt.Sprintf("%s=\"%s\"", label, escapeLabelValue(labelValue))
+		counts, sum := metrics[index].latency.cumulative()
+		for bucket, count := range counts {
+			fmt.Fprintf(builder, "%s%s_bucket{%s,le=\"%s\"} %d\n", metricsPrefix, name, labelPair, bucketBound(bucket), count)
+		}
+		fmt.Fprintf(builder, "%s%s_sum{%s} %s\n", metricsPrefix, name, labelPair, strconv.FormatFloat(sum, 'g', -1, 64))
+		fmt.Fprintf(builder, "%s%s_count{%s} %d\n", metricsPrefix, name, labelPair, counts[len(counts)-1])
+	}
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+// ServeHTTP serves metrics in Prometheus text exposition format
+func (m *EvaluationMetrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(writer)
+}
@@ -0,0 +1,119 @@
+package expressiontree
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createMetricsRuleSet(t *testing.T, backend CompileBackend, metrics *EvaluationMetrics) *RuleSet {
+	t.Helper()
+	patterns := CreatePatternRegistry()
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 1, Kind: SubstringPattern, Value: "x"}))
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{Patterns: patterns, Backend: backend, Metrics: metrics})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "exists", Source: "EXISTS(http.options.A)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "match", Source: "AND(EXISTS(http.options.A), MATCH(http.options.Value, 1))"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "const", Source: "FALSE()"}))
+	return ruleSet
+}
+
+func evaluateMetricsRecords(t *testing.T, ruleSet *RuleSet) {
+	t.Helper()
+	manager := CreateExecutionManager(ruleSet.Patterns())
+	records := []*HttpData{
+		{Options: map[string]any{"A": "a", "Value": "x"}},
+		{Options: map[string]any{"A": "a", "Value": struct{}{}}},
+		{Options: map[string]any{"Value": "x"}},
+	}
+	for _, data := range records {
+		_, _ = ruleSet.Evaluate(context.Background(), data, manager)
+	}
+}
+
+func TestEvaluationMetrics(t *testing.T) {
+	for _, backend := range []CompileBackend{ClosureBackend, BytecodeBackend} {
+		metrics := CreateEvaluationMetrics()
+		evaluateMetricsRecords(t, createMetricsRuleSet(t, backend, metrics))
+		var decoded map[string]map[string]jsonCallMetrics
+		assert.NoError(t, json.Unmarshal([]byte(metrics.String()), &decoded))
+		rules := decoded["rules"]
+		assert.Equal(t, []uint64{3, 2, 0}, []uint64{rules["exists"].Calls, *rules["exists"].Matches, rules["exists"].Errors})
+		assert.Equal(t, []uint64{3, 1, 1}, []uint64{rules["match"].Calls, *rules["match"].Matches, rules["match"].Errors})
+		assert.Equal(t, []uint64{3, 0, 0}, []uint64{rules["const"].Calls, *rules["const"].Matches, rules["const"].Errors})
+		assert.Equal(t, uint64(3), rules["match"].Latency.Count)
+		assert.Equal(t, uint64(3), rules["match"].Latency.Buckets["+Inf"])
+		// cached EXISTS calls manager once per record, MATCH only when EXISTS is true
+		options := decoded["manager"]["http.options"]
+		assert.Equal(t, uint64(3+2), options.Calls)
+		assert.Equal(t, uint64(1), options.Errors)
+		assert.Nil(t, options.Matches)
+		assert.Len(t, decoded["manager"], 1)
+	}
+}
+
+func TestEvaluationMetricsExpvar(t *testing.T) {
+	metrics := CreateEvaluationMetrics()
+	evaluateMetricsRecords(t, createMetricsRuleSet(t, ClosureBackend, metrics))
+	expvar.Publish("expressiontree_test_metrics", metrics)
+	assert.Same(t, metrics, expvar.Get("expressiontree_test_metrics"))
+	assert.True(t, json.Valid([]byte(expvar.Get("expressiontree_test_metrics").String())))
+	assert.Equal(t, `{"manager":{},"rules":{}}`, CreateEvaluationMetrics().String())
+}
+
+func TestEvaluationMetricsPrometheus(t *testing.T) {
+	metrics := CreateEvaluationMetrics()
+	evaluateMetricsRecords(t, createMetricsRuleSet(t, ClosureBackend, metrics))
+	server := httptest.NewServer(metrics)
+	defer server.Close()
+	response, responseError := http.Get(server.URL)
+	assert.NoError(t, responseError)
+	defer response.Body.Close()
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header.Get("Content-Type"))
+	body, bodyError := io.ReadAll(response.Body)
+	assert.NoError(t, bodyError)
+	text := string(body)
+	for _, line := range []string{
+		"# TYPE expressiontree_rule_evaluations_total counter",
+		`expressiontree_rule_evaluations_total{rule="const"} 3`,
+		`expressiontree_rule_matches_total{rule="exists"} 2`,
+		`expressiontree_rule_errors_total{rule="match"} 1`,
+		"# TYPE expressiontree_rule_duration_seconds histogram",
+		`expressiontree_rule_duration_seconds_bucket{rule="match",le="+Inf"} 3`,
+		`expressiontree_rule_duration_seconds_count{rule="match"} 3`,
+		`expressiontree_manager_calls_total{path="http.options"} 5`,
+		`expressiontree_manager_errors_total{path="http.options"} 1`,
+		`expressiontree_manager_call_duration_seconds_count{path="http.options"} 5`,
+	} {
+		assert.Contains(t, text, line+"\n")
+	}
+	// rules are sorted by name
+	assert.Less(t, strings.Index(text, `{rule="const"}`), strings.Index(text, `{rule="exists"}`))
+}
+
+func TestEvaluationMetricsHistogram(t *testing.T) {
+	metrics := CreateEvaluationMetrics()
+	predicate := metrics.Instrument("a \"quoted\"\nrule", func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		return true, nil
+	})
+	histogram := &metrics.rule("a \"quoted\"\nrule").latency
+	histogram.observe(20 * time.Microsecond)
+	histogram.observe(time.Millisecond)
+	histogram.observe(2 * time.Second)
+	counts, sum := histogram.cumulative()
+	assert.Equal(t, []uint64{0, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 3}, counts)
+	assert.InDelta(t, 2.00102, sum, 1e-9)
+	_, _ = predicate(context.Background(), &HttpData{}, nil)
+	builder := &strings.Builder{}
+	assert.NoError(t, metrics.WritePrometheus(builder))
+	assert.Contains(t, builder.String(), `expressiontree_rule_evaluations_total{rule="a \"quoted\"\nrule"} 1`+"\n")
+	assert.Contains(t, builder.String(), `expressiontree_rule_duration_seconds_bucket{rule="a \"quoted\"\nrule",le="0.001"} `)
+	assert.NotContains(t, builder.String(), "manager")
+}
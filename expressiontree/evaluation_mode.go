@@ -0,0 +1,75 @@
+package expressiontree
+
+import "context"
+
+// EvaluationMode defines how errors of leaves (manager calls) are handled by logical operators
+type EvaluationMode int
+
+const (
+	// StrictErrors - error of any evaluated operand is returned as error of whole expression
+	StrictErrors EvaluationMode = iota
+	// ErrorAsFalse - leaf which returns error is false, so NOT of failed leaf is true
+	ErrorAsFalse
+	// KleeneLogic - failed leaf is unknown: AND is false if any operand is false, OR is true if any operand is true,
+	// otherwise unknown operand makes result unknown. NOT of unknown is unknown.
+	// Unknown result of expression is returned as error (first error of unknown operands).
+	KleeneLogic
+)
+
+func createLeafWithMode(leaf PredicateWithError, mode EvaluationMode) PredicateWithError {
+	if mode != ErrorAsFalse {
+		return leaf
+	}
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		result, err := leaf(ctx, data, manager)
+		if err != nil {
+			// done context isn't failure of leaf
+			return false, evaluationContextError(ctx)
+		}
+		return result, nil
+	}
+}
+
+func createLogicalAndWithMode(mode EvaluationMode, predicates ...PredicateWithError) PredicateWithError {
+	if mode != KleeneLogic {
+		return createLogicalAnd(predicates...)
+	}
+	return createKleeneLogical(false, predicates...)
+}
+
+func createLogicalOrWithMode(mode EvaluationMode, predicates ...PredicateWithError) PredicateWithError {
+	if mode != KleeneLogic {
+		return createLogicalOr(predicates...)
+	}
+	return createKleeneLogical(true, predicates...)
+}
+
+// createKleeneLogical creates AND (decisiveResult == false) or OR (decisiveResult == true),
+// evaluation stops at first operand with decisive result
+func createKleeneLogical(decisiveResult bool, predicates ...PredicateWithError) PredicateWithError {
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		var unknownError error
+		for _, predicate := range predicates {
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			result, err := predicate(ctx, data, manager)
+			if err != nil {
+				if unknownError == nil {
+					unknownError = err
+				}
+				continue
+			}
+			if result == decisiveResult {
+				return decisiveResult, nil
+			}
+		}
+		if unknownError != nil {
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			return false, unknownError
+		}
+		return !decisiveResult, nil
+	}
+}
@@ -0,0 +1,61 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationMode(t *testing.T) {
+	someError := errors.New("some error")
+	// option F fails, T is true, N is false
+	testCases := []struct {
+		source         string
+		mode           EvaluationMode
+		expectedResult bool
+		expectedError  error
+	}{
+		{source: "OR(EXISTS(http.options.F), EXISTS(http.options.T))", mode: StrictErrors, expectedError: someError},
+		{source: "OR(EXISTS(http.options.F), EXISTS(http.options.T))", mode: ErrorAsFalse, expectedResult: true},
+		{source: "OR(EXISTS(http.options.F), EXISTS(http.options.T))", mode: KleeneLogic, expectedResult: true},
+		{source: "OR(EXISTS(http.options.F), EXISTS(http.options.N))", mode: ErrorAsFalse, expectedResult: false},
+		{source: "OR(EXISTS(http.options.F), EXISTS(http.options.N))", mode: KleeneLogic, expectedError: someError},
+		{source: "AND(EXISTS(http.options.F), EXISTS(http.options.N))", mode: StrictErrors, expectedError: someError},
+		{source: "AND(EXISTS(http.options.F), EXISTS(http.options.N))", mode: KleeneLogic, expectedResult: false},
+		{source: "AND(EXISTS(http.options.F), EXISTS(http.options.T))", mode: KleeneLogic, expectedError: someError},
+		{source: "AND(EXISTS(http.options.F), EXISTS(http.options.T))", mode: ErrorAsFalse, expectedResult: false},
+		{source: "NOT(EXISTS(http.options.F))", mode: StrictErrors, expectedError: someError},
+		{source: "NOT(EXISTS(http.options.F))", mode: ErrorAsFalse, expectedResult: true},
+		{source: "NOT(EXISTS(http.options.F))", mode: KleeneLogic, expectedError: someError},
+		{source: "OR(NOT(EXISTS(http.options.F)), AND(EXISTS(http.options.T), NOT(EXISTS(http.options.N))))", mode: KleeneLogic, expectedResult: true},
+		{source: "AND(OR(EXISTS(http.options.F), EXISTS(http.options.N)), EXISTS(http.options.N))", mode: KleeneLogic, expectedResult: false},
+		{source: "AND(OR(EXISTS(http.options.F), EXISTS(http.options.N)), EXISTS(http.options.T))", mode: KleeneLogic, expectedError: someError},
+	}
+	httpData := &HttpData{}
+	options := map[string]bool{"T": true, "N": false}
+	for _, testCase := range testCases {
+		controller := gomock.NewController(t)
+		mock := NewMockIExecutionManager(controller)
+		mock.EXPECT().CheckOptionExistence(gomock.Any(), gomock.Any(), httpData).DoAndReturn(func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+			if optionName == "F" {
+				return false, someError
+			}
+			return options[optionName], nil
+		}).AnyTimes()
+		node, nodeError := ParseRuleNode(testCase.source)
+		assert.NoError(t, nodeError, testCase.source)
+		expression, expressionError := CompileWithOptions(node, CompileOptions{Mode: testCase.mode})
+		assert.NoError(t, expressionError, testCase.source)
+		actualResult, actualError := expression(context.Background(), httpData, mock)
+		assert.Equal(t, testCase.expectedError, actualError, testCase.source)
+		assert.Equal(t, testCase.expectedResult, actualResult, testCase.source)
+		explanation, explainError := ExplainWithOptions(context.Background(), node, httpData, mock, CompileOptions{Mode: testCase.mode})
+		assert.NoError(t, explainError, testCase.source)
+		assert.Equal(t, testCase.expectedError, explanation.Err, testCase.source)
+		assert.Equal(t, testCase.expectedResult, explanation.Result, testCase.source)
+		controller.Finish()
+	}
+}
@@ -0,0 +1,136 @@
+package expressiontree
+
+import "context"
+
+const defaultParallelCostThreshold = 20.0
+
+// ParallelEvaluator evaluates expensive operands of AND/OR concurrently on bounded pool of workers.
+// Operand is expensive if its cost (see CostModel.EstimateCost) is not less than threshold, AND/OR is evaluated
+// in parallel only if it has at least two expensive operands. Expensive operands are started at once,
+// other operands are evaluated in source order by calling goroutine; if there is no free worker,
+// expensive operand is evaluated by calling goroutine too. Result is the same as result of sequential evaluation:
+// operands are taken into account in source order, so error of operand is returned only if sequential evaluation
+// reaches it (in KleeneLogic mode any decisive operand decides). Once AND/OR is decided, contexts of running
+// operands are canceled and evaluation waits for them, so manager should return soon after its context is done.
+// Manager methods are called concurrently, see IExecutionManager. ParallelEvaluator is safe for concurrent use.
+type ParallelEvaluator struct {
+	workers   chan struct{}
+	model     CostModel
+	threshold float64
+}
+
+type operandResult struct {
+	result bool
+	err    error
+}
+
+// CreateParallelEvaluator creates evaluator with workers shared by all evaluations,
+// default threshold (cost of MATCH of request or response body) is used if threshold isn't positive
+func CreateParallelEvaluator(workers int, model CostModel, threshold float64) *ParallelEvaluator {
+	if threshold <= 0 {
+		threshold = defaultParallelCostThreshold
+	}
+	return &ParallelEvaluator{workers: make(chan struct{}, max(workers, 1)), model: model, threshold: threshold}
+}
+
+func (e *ParallelEvaluator) acquire() bool {
+	select {
+	case e.workers <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *ParallelEvaluator) release() {
+	<-e.workers
+}
+
+// createLogical creates AND (decisiveResult == false) or OR (decisiveResult == true) evaluating expensive operands
+// in parallel, sequential predicate is returned if e is nil or there are less than two expensive operands
+func (e *ParallelEvaluator) createLogical(operands []Node, predicates []PredicateWithError, decisiveResult bool,
+	mode EvaluationMode, sequential PredicateWithError) PredicateWithError {
+	if e == nil {
+		return sequential
+	}
+	expensive := make([]bool, len(operands))
+	expensiveCount := 0
+	for index, operand := range operands {
+		if e.model.EstimateCost(operand) >= e.threshold {
+			expensive[index] = true
+			expensiveCount++
+		}
+	}
+	if expensiveCount < 2 {
+		return sequential
+	}
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		if contextError := evaluationContextError(ctx); contextError != nil {
+			return false, contextError
+		}
+		operandsCtx, cancel := context.WithCancel(ctx)
+		results := make([]operandResult, len(predicates))
+		started := make([]bool, len(predicates))
+		done := make([]bool, len(predicates))
+		completed := make(chan int, len(predicates))
+		running := 0
+		defer func() {
+			cancel()
+			for ; running > 0; running-- {
+				<-completed
+			}
+		}()
+		for index, predicate := range predicates {
+			if !expensive[index] || !e.acquire() {
+				continue
+			}
+			started[index] = true
+			running++
+			go func(index int, predicate PredicateWithError) {
+				defer func() {
+					e.release()
+					completed <- index
+				}()
+				results[index].result, results[index].err = predicate(operandsCtx, data, manager)
+			}(index, predicate)
+		}
+		var unknownError error
+		for next, predicate := range predicates {
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			if !started[next] {
+				results[next].result, results[next].err = predicate(operandsCtx, data, manager)
+				done[next] = true
+			}
+			for !done[next] {
+				index := <-completed
+				running--
+				done[index] = true
+				if mode == KleeneLogic && results[index].err == nil && results[index].result == decisiveResult {
+					return decisiveResult, nil
+				}
+			}
+			current := results[next]
+			if current.err != nil {
+				if mode != KleeneLogic {
+					return false, current.err
+				}
+				if unknownError == nil {
+					unknownError = current.err
+				}
+				continue
+			}
+			if current.result == decisiveResult {
+				return decisiveResult, nil
+			}
+		}
+		if unknownError != nil {
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			return false, unknownError
+		}
+		return !decisiveResult, nil
+	}
+}
@@ -0,0 +1,204 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var barrierTimeoutError = errors.New("barrier timeout")
+
+// bodyMatchManager calls functions instead of matching request and response bodies
+type bodyMatchManager struct {
+	*ExecutionManager
+	matchRequestBody  func(ctx context.Context) (bool, error)
+	matchResponseBody func(ctx context.Context) (bool, error)
+}
+
+func (m *bodyMatchManager) RecursiveMatchRequestBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchRequestBody(ctx)
+}
+
+func (m *bodyMatchManager) RecursiveMatchResponseBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchResponseBody(ctx)
+}
+
+// optionValuesManager returns results of options by name with delays, it counts concurrent calls
+type optionValuesManager struct {
+	*ExecutionManager
+	values    map[string]optionValue
+	active    atomic.Int32
+	maxActive atomic.Int32
+}
+
+func (m *optionValuesManager) call(ctx context.Context, key string) (bool, error) {
+	active := m.active.Add(1)
+	defer m.active.Add(-1)
+	for {
+		maxActive := m.maxActive.Load()
+		if active <= maxActive || m.maxActive.CompareAndSwap(maxActive, active) {
+			break
+		}
+	}
+	value := m.values[key]
+	select {
+	case <-time.After(value.delay):
+		return value.result, value.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (m *optionValuesManager) CheckOptionExistence(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+	return m.call(ctx, "exists "+optionName)
+}
+
+func (m *optionValuesManager) MatchOption(ctx context.Context, patternId uint, optionName string, data *HttpData) (bool, error) {
+	return m.call(ctx, "match "+optionName)
+}
+
+func TestParallelEvaluationIsConcurrent(t *testing.T) {
+	barrier := &sync.WaitGroup{}
+	barrier.Add(2)
+	// each body match waits for another, so sequential evaluation fails
+	wait := func(result bool) func(ctx context.Context) (bool, error) {
+		return func(ctx context.Context) (bool, error) {
+			barrier.Done()
+			waited := make(chan struct{})
+			go func() {
+				barrier.Wait()
+				close(waited)
+			}()
+			select {
+			case <-waited:
+				return result, nil
+			case <-time.After(5 * time.Second):
+				return false, barrierTimeoutError
+			}
+		}
+	}
+	manager := &bodyMatchManager{matchRequestBody: wait(false), matchResponseBody: wait(true)}
+	expression, expressionError := ParseRuleWithOptions("OR(MATCH(http.request.body, 1), MATCH(http.response.body, 1))",
+		CompileOptions{Parallel: CreateParallelEvaluator(4, CostModel{}, 0)})
+	assert.NoError(t, expressionError)
+	actualResult, actualError := expression(context.Background(), &HttpData{}, manager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+}
+
+func TestParallelEvaluationCancelsLosers(t *testing.T) {
+	testCases := []struct {
+		name           string
+		source         string
+		expectedResult bool
+	}{
+		{name: "OR", source: "OR(MATCH(http.request.body, 1), MATCH(http.response.body, 1))", expectedResult: true},
+		{name: "AND", source: "AND(NOT(MATCH(http.request.body, 1)), MATCH(http.response.body, 1))", expectedResult: false},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			var canceled atomic.Bool
+			manager := &bodyMatchManager{
+				matchRequestBody: func(ctx context.Context) (bool, error) {
+					return true, nil
+				},
+				matchResponseBody: func(ctx context.Context) (bool, error) {
+					select {
+					case <-ctx.Done():
+						canceled.Store(true)
+						return false, ctx.Err()
+					case <-time.After(5 * time.Second):
+						return true, nil
+					}
+				},
+			}
+			expression, expressionError := ParseRuleWithOptions(currentTestCase.source,
+				CompileOptions{Parallel: CreateParallelEvaluator(4, CostModel{}, 0)})
+			assert.NoError(t, expressionError)
+			actualResult, actualError := expression(context.Background(), &HttpData{}, manager)
+			assert.NoError(t, actualError)
+			assert.Equal(t, currentTestCase.expectedResult, actualResult)
+			// evaluation waits for canceled operands
+			assert.True(t, canceled.Load())
+		})
+	}
+}
+
+func TestParallelEvaluationWorkers(t *testing.T) {
+	manager := &optionValuesManager{values: map[string]optionValue{}}
+	operands := make([]Node, 6)
+	for index := range operands {
+		name := fmt.Sprintf("O%d", index)
+		manager.values["match "+name] = optionValue{result: true, delay: time.Millisecond}
+		operands[index] = CreateMatchNode(CreateDataPathWithSimpleContent(OptionsKey, name), 1)
+	}
+	// MATCH of options is expensive by threshold
+	expression, expressionError := CompileWithOptions(CreateAndNode(operands...),
+		CompileOptions{Parallel: CreateParallelEvaluator(2, CostModel{}, 4)})
+	assert.NoError(t, expressionError)
+	actualResult, actualError := expression(context.Background(), &HttpData{}, manager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+	// two workers and calling goroutine
+	assert.LessOrEqual(t, manager.maxActive.Load(), int32(3))
+	assert.Greater(t, manager.maxActive.Load(), int32(1))
+}
+
+// TestParallelEvaluationDifferential compares results and errors of parallel and sequential evaluation
+func TestParallelEvaluationDifferential(t *testing.T) {
+	optionNames := []string{"A", "B", "C", "D"}
+	random := rand.New(rand.NewSource(5))
+	// MATCH of options is expensive, EXISTS isn't
+	evaluator := CreateParallelEvaluator(3, CostModel{}, 4)
+	for iteration := 0; iteration < 100; iteration++ {
+		node := generateOptionsNode(random, optionNames, 3)
+		for _, mode := range []EvaluationMode{StrictErrors, ErrorAsFalse, KleeneLogic} {
+			expression, expressionError := CompileWithOptions(node, CompileOptions{Mode: mode})
+			parallelExpression, parallelExpressionError := CompileWithOptions(node, CompileOptions{Mode: mode, Parallel: evaluator})
+			if !assert.NoError(t, expressionError) || !assert.NoError(t, parallelExpressionError) {
+				return
+			}
+			for dataIteration := 0; dataIteration < 4; dataIteration++ {
+				manager := &optionValuesManager{values: generateOptionValues(random, optionNames)}
+				for _, name := range optionNames {
+					for _, key := range []string{"exists " + name, "match " + name} {
+						value := manager.values[key]
+						value.delay = time.Duration(random.Intn(200)) * time.Microsecond
+						manager.values[key] = value
+					}
+				}
+				expectedResult, expectedError := expression(context.Background(), &HttpData{}, manager)
+				actualResult, actualError := parallelExpression(context.Background(), &HttpData{}, manager)
+				description := fmt.Sprintf("%v, mode %d, values %v", node, mode, manager.values)
+				assert.Equal(t, expectedError, actualError, description)
+				assert.Equal(t, expectedResult, actualResult, description)
+			}
+		}
+	}
+}
+
+func TestParallelEvaluationTimeout(t *testing.T) {
+	manager := &bodyMatchManager{
+		matchRequestBody: func(ctx context.Context) (bool, error) {
+			<-ctx.Done()
+			return false, ctx.Err()
+		},
+		matchResponseBody: func(ctx context.Context) (bool, error) {
+			return false, nil
+		},
+	}
+	expression, expressionError := ParseRuleWithOptions("OR(MATCH(http.request.body, 1), MATCH(http.response.body, 1))",
+		CompileOptions{Parallel: CreateParallelEvaluator(4, CostModel{}, 0), Timeout: 10 * time.Millisecond})
+	assert.NoError(t, expressionError)
+	actualResult, actualError := expression(context.Background(), &HttpData{}, manager)
+	assert.ErrorIs(t, actualError, evaluationTimeoutError)
+	assert.False(t, actualResult)
+}
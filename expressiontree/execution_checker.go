@@ -1,5 +1,7 @@
 package expressiontree
 
+import "context"
+
 type IExecutionChecker interface {
 	IHttpDataChecker
 	IOptionsChecker
@@ -9,46 +11,46 @@ type IExecutionChecker interface {
 }
 
 type IHttpDataChecker interface {
-	RecursiveCheckHttpData(predicate Predicate, data *HttpData) (bool, error)
-	CheckHttpDataHost(predicate Predicate, data *HttpData) (bool, error)
-	CheckHttpDataProtocol(predicate Predicate, data *HttpData) (bool, error)
-	CheckHttpDataPort(predicate Predicate, data *HttpData) (bool, error)
-	CheckHttpDataHttpVersion(predicate Predicate, data *HttpData) (bool, error)
-	CheckHttpDataTimestamp(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckHttpData(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckHttpDataHost(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckHttpDataProtocol(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckHttpDataPort(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckHttpDataHttpVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckHttpDataTimestamp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IOptionsChecker interface {
-	RecursiveCheckOptions(predicate Predicate, data *HttpData) (bool, error)
-	CheckOptionExistence(optionName string, data *HttpData) (bool, error)
-	CheckOption(predicate Predicate, optionName string, data *HttpData) (bool, error)
+	RecursiveCheckOptions(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckOptionExistence(ctx context.Context, optionName string, data *HttpData) (bool, error)
+	CheckOption(ctx context.Context, predicate Predicate, optionName string, data *HttpData) (bool, error)
 }
 
 type IGeoIpChecker interface {
-	RecursiveCheckGeoIp(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpCountry(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpCountryCode(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpCity(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpLat(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpLon(predicate Predicate, data *HttpData) (bool, error)
-	CheckGeoIpAccuracyRadius(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckGeoIp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpCountry(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpCountryCode(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpCity(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpLat(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpLon(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckGeoIpAccuracyRadius(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IOsChecker interface {
-	RecursiveCheckOs(predicate Predicate, data *HttpData) (bool, error)
-	CheckOsName(predicate Predicate, data *HttpData) (bool, error)
-	CheckOsVersion(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckOs(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckOsName(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckOsVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IBrowserChecker interface {
-	RecursiveCheckBrowser(predicate Predicate, data *HttpData) (bool, error)
-	CheckBrowserName(predicate Predicate, data *HttpData) (bool, error)
-	CheckBrowserVersion(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckBrowser(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckBrowserName(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckBrowserVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IBasicAuthChecker interface {
-	RecursiveCheckBasicAuth(predicate Predicate, data *HttpData) (bool, error)
-	CheckBasicAuthUsername(predicate Predicate, data *HttpData) (bool, error)
-	CheckBasicAuthPassword(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckBasicAuth(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckBasicAuthUsername(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckBasicAuthPassword(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IClientChecker interface {
@@ -56,33 +58,33 @@ type IClientChecker interface {
 	IOsChecker
 	IBrowserChecker
 	IBasicAuthChecker
-	RecursiveCheckClient(predicate Predicate, data *HttpData) (bool, error)
-	CheckClientId(predicate Predicate, data *HttpData) (bool, error)
-	CheckClientIp(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckClient(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckClientId(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckClientIp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IRequestGetChecker interface {
-	RecursiveCheckRequestGet(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestGetValueExistence(path ContentPath, data *HttpData) (bool, error)
-	RecursiveCheckRequestGetValue(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestGet(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestGetValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestGetValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestPostChecker interface {
-	RecursiveCheckRequestPost(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestPostValueExistence(path ContentPath, data *HttpData) (bool, error)
-	RecursiveCheckRequestPostValue(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestPost(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestPostValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestPostValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestHeadersChecker interface {
-	RecursiveCheckRequestHeaders(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestHeaderValueExistence(path ContentPath, data *HttpData) (bool, error)
-	RecursiveCheckRequestHeaderValue(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestHeaders(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestHeaderValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestHeaderValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestCookiesChecker interface {
-	RecursiveCheckRequestCookies(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestCookieValueExistence(path ContentPath, data *HttpData) (bool, error)
-	RecursiveCheckRequestCookieValue(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestCookies(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestCookieValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckRequestCookieValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestChecker interface {
@@ -90,29 +92,29 @@ type IRequestChecker interface {
 	IRequestPostChecker
 	IRequestHeadersChecker
 	IRequestCookiesChecker
-	RecursiveCheckRequest(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestId(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestPath(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestPaths(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestPathsElement(predicate Predicate, index int, path ContentPath, data *HttpData) (bool, error)
-	CheckRequestQuery(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestMethod(predicate Predicate, data *HttpData) (bool, error)
-	RecursiveCheckRequestBody(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
-	CheckRequestTime(predicate Predicate, data *HttpData) (bool, error)
-	CheckRequestLength(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckRequest(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestId(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestPath(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestPaths(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestPathsElement(ctx context.Context, predicate Predicate, index int, path ContentPath, data *HttpData) (bool, error)
+	CheckRequestQuery(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestMethod(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckRequestBody(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	CheckRequestTime(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckRequestLength(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
 
 type IResponseHeadersChecker interface {
-	RecursiveCheckResponseHeaders(predicate Predicate, data *HttpData) (bool, error)
-	CheckResponseHeaderValueExistence(path ContentPath, data *HttpData) (bool, error)
-	RecursiveCheckResponseHeaderValue(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckResponseHeaders(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckResponseHeaderValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error)
+	RecursiveCheckResponseHeaderValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IResponseChecker interface {
 	IResponseHeadersChecker
-	RecursiveCheckResponse(predicate Predicate, data *HttpData) (bool, error)
-	RecursiveCheckResponseBody(predicate Predicate, path ContentPath, data *HttpData) (bool, error)
-	CheckResponseCode(predicate Predicate, data *HttpData) (bool, error)
-	CheckResponseSource(predicate Predicate, data *HttpData) (bool, error)
-	CheckResponseLength(predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckResponse(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	RecursiveCheckResponseBody(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error)
+	CheckResponseCode(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckResponseSource(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
+	CheckResponseLength(ctx context.Context, predicate Predicate, data *HttpData) (bool, error)
 }
@@ -0,0 +1,296 @@
+package expressiontree
+
+import "context"
+
+// ExecutionChecker is implementation of IExecutionChecker over HttpData.
+// Check is true if predicate is true for any of checked values (for recursive checks - for any leaf),
+// predicate error (e.g. coercion error) is returned only if predicate is false for all values.
+// Values are checked until ctx is done, then evaluation error is returned.
+// ExecutionChecker doesn't change HttpData and is safe for concurrent use.
+type ExecutionChecker struct {
+}
+
+func CreateExecutionChecker() *ExecutionChecker {
+	return &ExecutionChecker{}
+}
+
+func checkValues(ctx context.Context, predicate Predicate, values []any) (bool, error) {
+	var firstError error
+	for _, value := range values {
+		if contextError := evaluationContextError(ctx); contextError != nil {
+			return false, contextError
+		}
+		result, predicateError := predicate(value)
+		if predicateError != nil {
+			if firstError == nil {
+				firstError = predicateError
+			}
+			continue
+		}
+		if result {
+			return true, nil
+		}
+	}
+	return false, firstError
+}
+
+func (c *ExecutionChecker) RecursiveCheckHttpData(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, httpDataValues(data))
+}
+
+func (c *ExecutionChecker) CheckHttpDataHost(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Host})
+}
+
+func (c *ExecutionChecker) CheckHttpDataProtocol(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Protocol})
+}
+
+func (c *ExecutionChecker) CheckHttpDataPort(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Port})
+}
+
+func (c *ExecutionChecker) CheckHttpDataHttpVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.HttpVersion})
+}
+
+func (c *ExecutionChecker) CheckHttpDataTimestamp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Timestamp})
+}
+
+func (c *ExecutionChecker) RecursiveCheckOptions(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, optionsValues(data))
+}
+
+func (c *ExecutionChecker) CheckOptionExistence(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+	_, exists := data.Options[optionName]
+	return exists, nil
+}
+
+func (c *ExecutionChecker) CheckOption(ctx context.Context, predicate Predicate, optionName string, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, optionValues(optionName, data))
+}
+
+func (c *ExecutionChecker) RecursiveCheckClient(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, clientValues(data))
+}
+
+func (c *ExecutionChecker) CheckClientId(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Id})
+}
+
+func (c *ExecutionChecker) CheckClientIp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Ip})
+}
+
+func (c *ExecutionChecker) RecursiveCheckGeoIp(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpValues(data)
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpCountry(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Country })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpCountryCode(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.CountryCode })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpCity(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.City })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpLat(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Lat })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpLon(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Lon })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) CheckGeoIpAccuracyRadius(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.AccuracyRadius })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return checkValues(ctx, predicate, values)
+}
+
+func (c *ExecutionChecker) RecursiveCheckOs(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, osValues(data))
+}
+
+func (c *ExecutionChecker) CheckOsName(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Os.Name})
+}
+
+func (c *ExecutionChecker) CheckOsVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Os.Version})
+}
+
+func (c *ExecutionChecker) RecursiveCheckBrowser(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, browserValues(data))
+}
+
+func (c *ExecutionChecker) CheckBrowserName(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Browser.Name})
+}
+
+func (c *ExecutionChecker) CheckBrowserVersion(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.Browser.Version})
+}
+
+func (c *ExecutionChecker) RecursiveCheckBasicAuth(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, basicAuthValues(data))
+}
+
+func (c *ExecutionChecker) CheckBasicAuthUsername(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.BasicAuth.Username})
+}
+
+func (c *ExecutionChecker) CheckBasicAuthPassword(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Client.BasicAuth.Password})
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequest(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, requestValues(data))
+}
+
+func (c *ExecutionChecker) CheckRequestId(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Id})
+}
+
+func (c *ExecutionChecker) CheckRequestPath(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Path})
+}
+
+func (c *ExecutionChecker) CheckRequestPaths(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, requestPathsValues(data))
+}
+
+func (c *ExecutionChecker) CheckRequestPathsElement(ctx context.Context, predicate Predicate, index int, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, requestPathsElementValues(index, data))
+}
+
+func (c *ExecutionChecker) CheckRequestQuery(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Query})
+}
+
+func (c *ExecutionChecker) CheckRequestMethod(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Method})
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestBody(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, bodyValues(data.Request.Body, path))
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestGet(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapValues(data.Request.Get))
+}
+
+func (c *ExecutionChecker) CheckRequestGetValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error) {
+	return multiMapContentExists(data.Request.Get, path, false), nil
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestGetValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapContentValues(data.Request.Get, path, false))
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestPost(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapValues(data.Request.Post))
+}
+
+func (c *ExecutionChecker) CheckRequestPostValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error) {
+	return multiMapContentExists(data.Request.Post, path, false), nil
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestPostValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapContentValues(data.Request.Post, path, false))
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestHeaders(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapValues(data.Request.Headers))
+}
+
+func (c *ExecutionChecker) CheckRequestHeaderValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error) {
+	return multiMapContentExists(data.Request.Headers, path, true), nil
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestHeaderValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapContentValues(data.Request.Headers, path, true))
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestCookies(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapValues(data.Request.Cookies))
+}
+
+func (c *ExecutionChecker) CheckRequestCookieValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error) {
+	return multiMapContentExists(data.Request.Cookies, path, false), nil
+}
+
+func (c *ExecutionChecker) RecursiveCheckRequestCookieValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapContentValues(data.Request.Cookies, path, false))
+}
+
+func (c *ExecutionChecker) CheckRequestTime(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Time})
+}
+
+func (c *ExecutionChecker) CheckRequestLength(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Request.Length})
+}
+
+func (c *ExecutionChecker) RecursiveCheckResponse(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, responseValues(data))
+}
+
+func (c *ExecutionChecker) RecursiveCheckResponseBody(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, bodyValues(data.Response.Body, path))
+}
+
+func (c *ExecutionChecker) CheckResponseCode(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Response.Code})
+}
+
+func (c *ExecutionChecker) CheckResponseSource(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Response.Source})
+}
+
+func (c *ExecutionChecker) CheckResponseLength(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, []any{data.Response.Length})
+}
+
+func (c *ExecutionChecker) RecursiveCheckResponseHeaders(ctx context.Context, predicate Predicate, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapValues(data.Response.Headers))
+}
+
+func (c *ExecutionChecker) CheckResponseHeaderValueExistence(ctx context.Context, path ContentPath, data *HttpData) (bool, error) {
+	return multiMapContentExists(data.Response.Headers, path, true), nil
+}
+
+func (c *ExecutionChecker) RecursiveCheckResponseHeaderValue(ctx context.Context, predicate Predicate, path ContentPath, data *HttpData) (bool, error) {
+	return checkValues(ctx, predicate, multiMapContentValues(data.Response.Headers, path, true))
+}
@@ -1,6 +1,9 @@
 package expressiontree
 
-// IExecutionManager is facade
+// IExecutionManager is facade.
+// All methods are called concurrently (for the same HttpData) by predicates compiled with CompileOptions.Parallel,
+// so such manager must be safe for concurrent use and should return soon after ctx is done.
+// Default ExecutionManager is safe for concurrent use (if its pattern matcher is).
 type IExecutionManager interface {
 	IExecutionMatcher
 	IExecutionChecker
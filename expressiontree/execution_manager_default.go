@@ -0,0 +1,19 @@
+package expressiontree
+
+// ExecutionManager is default implementation of IExecutionManager over HttpData
+type ExecutionManager struct {
+	*ExecutionChecker
+	*ExecutionMatcher
+}
+
+var _ IExecutionManager = (*ExecutionManager)(nil)
+
+func CreateExecutionManager(patterns IPatternMatcher) *ExecutionManager {
+	return &ExecutionManager{ExecutionChecker: CreateExecutionChecker(), ExecutionMatcher: CreateExecutionMatcher(patterns)}
+}
+
+// WithLiteralRecorder returns manager which reports literals of multi literal patterns found by MATCH
+// to recorder (see ExecutionMatcher.WithLiteralRecorder), m isn't changed
+func (m *ExecutionManager) WithLiteralRecorder(recorder LiteralRecorder) *ExecutionManager {
+	return &ExecutionManager{ExecutionChecker: m.ExecutionChecker, ExecutionMatcher: m.ExecutionMatcher.WithLiteralRecorder(recorder)}
+}
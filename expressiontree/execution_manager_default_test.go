@@ -0,0 +1,163 @@
+package expressiontree
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pattern id -> substring
+type substringPatterns map[uint]string
+
+func (p substringPatterns) MatchPattern(patternId uint, value string) (bool, error) {
+	pattern, exists := p[patternId]
+	if !exists {
+		return false, unknownPatternError
+	}
+	return strings.Contains(value, pattern), nil
+}
+
+func createSampleHttpData() *HttpData {
+	return &HttpData{
+		Host:        "example.com",
+		Protocol:    "https",
+		Port:        443,
+		HttpVersion: "HTTP/1.1",
+		Timestamp:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Options:     map[string]any{"IDDQD": "god", "level": map[string]any{"value": 3}},
+		Client: ClientData{
+			Id:        "client-1",
+			Ip:        "10.0.0.1",
+			GeoIp:     &GeoIpData{Country: "Germany", CountryCode: "DE", City: "Berlin", Lat: 52.5, Lon: 13.4, AccuracyRadius: 20},
+			Os:        OsData{Name: "Linux", Version: "6.1"},
+			Browser:   BrowserData{Name: "Firefox", Version: "125.0"},
+			BasicAuth: BasicAuthData{Username: "admin", Password: "secret"},
+		},
+		Request: RequestData{
+			Id:      "request-1",
+			Path:    "/api/v1/users",
+			Paths:   []string{"api", "v1", "users"},
+			Query:   "filter=%7B%22name%22%3A%22bob%22%7D&page=2",
+			Method:  "POST",
+			Body:    []byte(`{"user": {"name": "bob", "age": 42, "tags": ["a", "b"]}}`),
+			Get:     map[string][]string{"page": {"2"}, "filter": {`{"name":"bob"}`}},
+			Post:    map[string][]string{"comment": {"hello"}},
+			Headers: map[string][]string{"Content-Type": {"application/json"}, "X-Token": {"abc"}},
+			Time:    1500 * time.Millisecond,
+			Cookies: map[string][]string{"session": {"s1"}},
+			Length:  2048,
+		},
+		Response: ResponseData{
+			Body:    []byte("plain text response"),
+			Code:    503,
+			Source:  "upstream",
+			Headers: map[string][]string{"Server": {"nginx"}},
+			Length:  19,
+		},
+	}
+}
+
+func TestExecutionManagerRules(t *testing.T) {
+	manager := CreateExecutionManager(substringPatterns{1: "bob", 2: "nginx", 3: "Fire", 4: "zzz"})
+	httpData := createSampleHttpData()
+	noGeoIpData := createSampleHttpData()
+	noGeoIpData.Client.GeoIp = nil
+	nullData := createSampleHttpData()
+	nullData.Options["empty"] = nil
+	nullData.Request.Body = []byte(`{"user": {"name": null, "tags": [null, "bob"]}, "id": null}`)
+	testCases := []struct {
+		source         string
+		data           *HttpData
+		expectedResult bool
+		expectedError  error
+	}{
+		{source: `CHECK(http.host == "example.com")`, expectedResult: true},
+		{source: "CHECK(http.port == 443)", expectedResult: true},
+		{source: `CHECK(http.timestamp > "2024-01-01T00:00:00Z")`, expectedResult: true},
+		{source: "CHECK(http == 443)", expectedResult: true},
+		{source: `CHECK(http.options.IDDQD == "god")`, expectedResult: true},
+		{source: "CHECK(http.options == 3)", expectedResult: true},
+		{source: "EXISTS(http.options.IDDQD)", expectedResult: true},
+		{source: "EXISTS(http.options.IDKFA)", expectedResult: false},
+		{source: `CHECK(http.client.ip STARTSWITH "10.")`, expectedResult: true},
+		{source: `CHECK(http.client.geoip.country_code IN ("DE", "FR"))`, expectedResult: true},
+		{source: "CHECK(http.client.geoip.lat BETWEEN 50 AND 55)", expectedResult: true},
+		{source: `CHECK(http.client.geoip == "Berlin")`, expectedResult: true},
+		{source: `CHECK(http.client.geoip.country_code == "DE")`, data: noGeoIpData, expectedError: noGeoIpDataError},
+		{source: `CHECK(http.client == "Firefox")`, data: noGeoIpData, expectedResult: true},
+		{source: `CHECK(http.client.os.name == "Linux")`, expectedResult: true},
+		{source: `CHECK(http.client.browser.version >= 100)`, expectedResult: true},
+		{source: `CHECK(http.client.basic_auth.username == "admin")`, expectedResult: true},
+		{source: `CHECK(http.client.basic_auth == "secret")`, expectedResult: true},
+		{source: `CHECK(http.request.paths == "v1")`, expectedResult: true},
+		{source: `CHECK(http.request.paths.2 == "users")`, expectedResult: true},
+		{source: `CHECK(http.request.paths.5 == "users")`, expectedResult: false},
+		{source: `CHECK(http.request.method IN ("POST", "PUT"))`, expectedResult: true},
+		{source: `CHECK(http.request.body.user.name == "bob")`, expectedResult: true},
+		{source: "CHECK(http.request.body.user.age > 40)", expectedResult: true},
+		{source: `CHECK(http.request.body.user.tags.1 == "b")`, expectedResult: true},
+		{source: `CHECK(http.request.body == "a")`, expectedResult: true},
+		{source: `CHECK(http.request.get.page == 2)`, expectedResult: true},
+		{source: `CHECK(http.request.get.filter.name == "bob")`, expectedResult: true},
+		{source: "EXISTS(http.request.get.filter.name)", expectedResult: true},
+		{source: "EXISTS(http.request.get.filter.age)", expectedResult: false},
+		{source: `CHECK(http.request.post.comment CONTAINS "ell")`, expectedResult: true},
+		{source: "EXISTS(http.request.headers.x-token)", expectedResult: true},
+		{source: `CHECK(http.request.headers.content-type == "application/json")`, expectedResult: true},
+		{source: `CHECK(http.request.cookies.session == "s1")`, expectedResult: true},
+		{source: `CHECK(http.request.time < "2s")`, expectedResult: true},
+		{source: "CHECK(http.request.time > 1)", expectedResult: true},
+		{source: "CHECK(http.request.length >= 1e3)", expectedResult: true},
+		{source: `CHECK(http.request == "abc")`, expectedResult: true},
+		{source: "CHECK(http.response.code > 499)", expectedResult: true},
+		{source: "CHECK(http.response.code == 503.0)", expectedResult: true},
+		{source: `CHECK(http.response.body CONTAINS "text")`, expectedResult: true},
+		{source: `CHECK(http.response.headers.server == "nginx")`, expectedResult: true},
+		{source: "EXISTS(http.response.headers.Location)", expectedResult: false},
+		{source: `CHECK(http.response == "upstream")`, expectedResult: true},
+		{source: "CHECK(http.request.method > 10)", expectedError: coercionError},
+		{source: "CHECK(http.request.headers > 10)", expectedError: coercionError},
+		{source: "MATCH(http.request.body, 1)", expectedResult: true},
+		{source: "MATCH(http.request.body.user.tags, 1)", expectedResult: false},
+		{source: "MATCH(http.response.headers, 2)", expectedResult: true},
+		{source: "MATCH(http.client.browser.name, 3)", expectedResult: true},
+		{source: "MATCH(http, 4)", expectedResult: false},
+		{source: "MATCH(http, 5)", expectedError: unknownPatternError},
+		{source: "MATCH(http.client.geoip, 1)", data: noGeoIpData, expectedError: noGeoIpDataError},
+		{source: "MATCH(http.request.body, 1)", data: nullData, expectedResult: true},
+		{source: "MATCH(http.request.body.user.name, 1)", data: nullData, expectedResult: false},
+		{source: "MATCH(http.request.body.id, 1)", data: nullData, expectedResult: false},
+		{source: "MATCH(http.options.empty, 1)", data: nullData, expectedResult: false},
+		{source: "MATCH(http.options, 4)", data: nullData, expectedResult: false},
+		{source: "MATCH(http, 4)", data: nullData, expectedResult: false},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.source, func(t *testing.T) {
+			data := currentTestCase.data
+			if data == nil {
+				data = httpData
+			}
+			expression, expressionError := ParseRule(currentTestCase.source)
+			assert.NoError(t, expressionError)
+			actualResult, actualError := expression(context.Background(), data, manager)
+			assert.Equal(t, currentTestCase.expectedResult, actualResult)
+			assert.ErrorIs(t, actualError, currentTestCase.expectedError)
+		})
+	}
+}
+
+func TestExecutionMatcherWithoutPatterns(t *testing.T) {
+	manager := CreateExecutionManager(nil)
+	actualResult, actualError := manager.MatchHttpDataHost(context.Background(), 1, createSampleHttpData())
+	assert.False(t, actualResult)
+	assert.Equal(t, unknownPatternError, actualError)
+	// typed nil is the same as nil
+	manager = CreateExecutionManager((*substringPatterns)(nil))
+	actualResult, actualError = manager.MatchHttpDataHost(context.Background(), 1, createSampleHttpData())
+	assert.False(t, actualResult)
+	assert.Equal(t, unknownPatternError, actualError)
+}
@@ -1,5 +1,7 @@
 package expressiontree
 
+import "context"
+
 type IExecutionMatcher interface {
 	IHttpDataMatcher
 	IOptionsMatcher
@@ -9,45 +11,45 @@ type IExecutionMatcher interface {
 }
 
 type IHttpDataMatcher interface {
-	RecursiveMatchHttpData(patternId uint, data *HttpData) (bool, error)
-	MatchHttpDataHost(patternId uint, data *HttpData) (bool, error)
-	MatchHttpDataProtocol(patternId uint, data *HttpData) (bool, error)
-	MatchHttpDataPort(patternId uint, data *HttpData) (bool, error)
-	MatchHttpDataHttpVersion(patternId uint, data *HttpData) (bool, error)
-	MatchHttpDataTimestamp(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchHttpData(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchHttpDataHost(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchHttpDataProtocol(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchHttpDataPort(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchHttpDataHttpVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchHttpDataTimestamp(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IOptionsMatcher interface {
-	RecursiveMatchOptions(patternId uint, data *HttpData) (bool, error)
-	MatchOption(patternId uint, name string, data *HttpData) (bool, error)
+	RecursiveMatchOptions(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchOption(ctx context.Context, patternId uint, name string, data *HttpData) (bool, error)
 }
 
 type IGeoIpMatcher interface {
-	RecursiveMatchGeoIp(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpCountry(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpCountryCode(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpCity(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpLat(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpLon(patternId uint, data *HttpData) (bool, error)
-	MatchGeoIpAccuracyRadius(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchGeoIp(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpCountry(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpCountryCode(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpCity(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpLat(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpLon(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchGeoIpAccuracyRadius(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IOsMatcher interface {
-	RecursiveMatchOs(patternId uint, data *HttpData) (bool, error)
-	MatchOsName(patternId uint, data *HttpData) (bool, error)
-	MatchOsVersion(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchOs(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchOsName(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchOsVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IBrowserMatcher interface {
-	RecursiveMatchBrowser(patternId uint, data *HttpData) (bool, error)
-	MatchBrowserName(patternId uint, data *HttpData) (bool, error)
-	MatchBrowserVersion(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchBrowser(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchBrowserName(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchBrowserVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IBasicAuthMatcher interface {
-	RecursiveMatchBasicAuth(patternId uint, data *HttpData) (bool, error)
-	MatchBasicAuthUsername(patternId uint, data *HttpData) (bool, error)
-	MatchBasicAuthPassword(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchBasicAuth(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchBasicAuthUsername(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchBasicAuthPassword(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IClientMatcher interface {
@@ -55,29 +57,29 @@ type IClientMatcher interface {
 	IOsMatcher
 	IBrowserMatcher
 	IBasicAuthMatcher
-	RecursiveMatchClient(patternId uint, data *HttpData) (bool, error)
-	MatchClientId(patternId uint, data *HttpData) (bool, error)
-	MatchClientIp(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchClient(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchClientId(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchClientIp(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IRequestGetMatcher interface {
-	RecursiveMatchRequestGet(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchRequestGetValue(patternId uint, path ContentPath, data *HttpData) (bool, error)
+	RecursiveMatchRequestGet(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequestGetValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestPostMatcher interface {
-	RecursiveMatchRequestPost(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchRequestPostValue(patternId uint, path ContentPath, data *HttpData) (bool, error)
+	RecursiveMatchRequestPost(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequestPostValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestHeadersMatcher interface {
-	RecursiveMatchRequestHeaders(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchRequestHeaderValue(patternId uint, path ContentPath, data *HttpData) (bool, error)
+	RecursiveMatchRequestHeaders(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequestHeaderValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestCookiesMatcher interface {
-	RecursiveMatchRequestCookies(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchRequestCookieValue(patternId uint, path ContentPath, data *HttpData) (bool, error)
+	RecursiveMatchRequestCookies(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequestCookieValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IRequestMatcher interface {
@@ -85,28 +87,28 @@ type IRequestMatcher interface {
 	IRequestPostMatcher
 	IRequestHeadersMatcher
 	IRequestCookiesMatcher
-	RecursiveMatchRequest(patternId uint, data *HttpData) (bool, error)
-	MatchRequestId(patternId uint, data *HttpData) (bool, error)
-	MatchRequestPath(patternId uint, data *HttpData) (bool, error)
-	MatchRequestPaths(patternId uint, data *HttpData) (bool, error)
-	MatchRequestPathsElement(patternId uint, index int, path ContentPath, data *HttpData) (bool, error)
-	MatchRequestQuery(patternId uint, data *HttpData) (bool, error)
-	MatchRequestMethod(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchRequestBody(patternId uint, path ContentPath, data *HttpData) (bool, error)
-	MatchRequestTime(patternId uint, data *HttpData) (bool, error)
-	MatchRequestLength(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequest(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestId(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestPath(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestPaths(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestPathsElement(ctx context.Context, patternId uint, index int, path ContentPath, data *HttpData) (bool, error)
+	MatchRequestQuery(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestMethod(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchRequestBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
+	MatchRequestTime(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchRequestLength(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
 
 type IResponseHeadersMatcher interface {
-	RecursiveMatchResponseHeaders(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchResponseHeaderValue(patternId uint, path ContentPath, data *HttpData) (bool, error)
+	RecursiveMatchResponseHeaders(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchResponseHeaderValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
 }
 
 type IResponseMatcher interface {
 	IResponseHeadersMatcher
-	RecursiveMatchResponse(patternId uint, data *HttpData) (bool, error)
-	RecursiveMatchResponseBody(patternId uint, path ContentPath, data *HttpData) (bool, error)
-	MatchResponseCode(patternId uint, data *HttpData) (bool, error)
-	MatchResponseSource(patternId uint, data *HttpData) (bool, error)
-	MatchResponseLength(patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchResponse(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	RecursiveMatchResponseBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error)
+	MatchResponseCode(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchResponseSource(ctx context.Context, patternId uint, data *HttpData) (bool, error)
+	MatchResponseLength(ctx context.Context, patternId uint, data *HttpData) (bool, error)
 }
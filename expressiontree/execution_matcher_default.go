@@ -0,0 +1,310 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var unknownPatternError = errors.New("unknown pattern")
+
+// IPatternMatcher matches string value with pattern by id
+type IPatternMatcher interface {
+	MatchPattern(patternId uint, value string) (bool, error)
+}
+
+// ILiteralPatternMatcher is optional interface of IPatternMatcher, it returns literals of multi literal pattern
+// found in value by the same pass which matches value
+type ILiteralPatternMatcher interface {
+	IPatternMatcher
+	MatchPatternLiterals(patternId uint, value string) (bool, []string, error)
+}
+
+// LiteralRecorder receives literals of multi literal pattern found by MATCH in matched value
+type LiteralRecorder func(patternId uint, literals []string)
+
+// ExecutionMatcher is implementation of IExecutionMatcher over HttpData.
+// Values are converted to string (see coercion rules in predicate_operations.go) and matched by IPatternMatcher,
+// match is true if any of values (for recursive matches - any leaf) is matched, nil values (e.g. JSON null)
+// aren't matched.
+// ExecutionMatcher is safe for concurrent use if IPatternMatcher is safe for concurrent use.
+type ExecutionMatcher struct {
+	patterns       IPatternMatcher
+	recordLiterals LiteralRecorder
+}
+
+// CreateExecutionMatcher creates matcher, nil pointer (e.g. nil *PatternRegistry) is the same as nil patterns
+func CreateExecutionMatcher(patterns IPatternMatcher) *ExecutionMatcher {
+	if value := reflect.ValueOf(patterns); value.Kind() == reflect.Pointer && value.IsNil() {
+		patterns = nil
+	}
+	return &ExecutionMatcher{patterns: patterns}
+}
+
+// WithLiteralRecorder returns matcher which reports literals found by MATCH to recorder (match stops
+// at the first matched value, so literals of this value are reported), m isn't changed.
+// Literals are reported only if IPatternMatcher implements ILiteralPatternMatcher.
+func (m *ExecutionMatcher) WithLiteralRecorder(recorder LiteralRecorder) *ExecutionMatcher {
+	return &ExecutionMatcher{patterns: m.patterns, recordLiterals: recorder}
+}
+
+func (m *ExecutionMatcher) matchValues(ctx context.Context, patternId uint, values []any) (bool, error) {
+	if m.patterns == nil {
+		return false, unknownPatternError
+	}
+	literalPatterns, findsLiterals := m.patterns.(ILiteralPatternMatcher)
+	findsLiterals = findsLiterals && m.recordLiterals != nil
+	return checkValues(ctx, func(value any) (bool, error) {
+		if value == nil {
+			return false, nil
+		}
+		stringValue, stringError := toString(value)
+		if stringError != nil {
+			return false, stringError
+		}
+		if !findsLiterals {
+			return m.patterns.MatchPattern(patternId, stringValue)
+		}
+		result, literals, matchError := literalPatterns.MatchPatternLiterals(patternId, stringValue)
+		if result && len(literals) > 0 {
+			m.recordLiterals(patternId, literals)
+		}
+		return result, matchError
+	}, values)
+}
+
+func (m *ExecutionMatcher) RecursiveMatchHttpData(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, httpDataValues(data))
+}
+
+func (m *ExecutionMatcher) MatchHttpDataHost(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Host})
+}
+
+func (m *ExecutionMatcher) MatchHttpDataProtocol(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Protocol})
+}
+
+func (m *ExecutionMatcher) MatchHttpDataPort(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Port})
+}
+
+func (m *ExecutionMatcher) MatchHttpDataHttpVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.HttpVersion})
+}
+
+func (m *ExecutionMatcher) MatchHttpDataTimestamp(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Timestamp})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchOptions(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, optionsValues(data))
+}
+
+func (m *ExecutionMatcher) MatchOption(ctx context.Context, patternId uint, name string, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, optionValues(name, data))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchClient(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, clientValues(data))
+}
+
+func (m *ExecutionMatcher) MatchClientId(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Id})
+}
+
+func (m *ExecutionMatcher) MatchClientIp(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Ip})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchGeoIp(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpValues(data)
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpCountry(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Country })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpCountryCode(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.CountryCode })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpCity(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.City })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpLat(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Lat })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpLon(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.Lon })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) MatchGeoIpAccuracyRadius(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	values, valuesError := geoIpFieldValues(data, func(geoIp *GeoIpData) any { return geoIp.AccuracyRadius })
+	if valuesError != nil {
+		return false, valuesError
+	}
+	return m.matchValues(ctx, patternId, values)
+}
+
+func (m *ExecutionMatcher) RecursiveMatchOs(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, osValues(data))
+}
+
+func (m *ExecutionMatcher) MatchOsName(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Os.Name})
+}
+
+func (m *ExecutionMatcher) MatchOsVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Os.Version})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchBrowser(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, browserValues(data))
+}
+
+func (m *ExecutionMatcher) MatchBrowserName(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Browser.Name})
+}
+
+func (m *ExecutionMatcher) MatchBrowserVersion(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.Browser.Version})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchBasicAuth(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, basicAuthValues(data))
+}
+
+func (m *ExecutionMatcher) MatchBasicAuthUsername(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.BasicAuth.Username})
+}
+
+func (m *ExecutionMatcher) MatchBasicAuthPassword(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Client.BasicAuth.Password})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequest(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, requestValues(data))
+}
+
+func (m *ExecutionMatcher) MatchRequestId(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Id})
+}
+
+func (m *ExecutionMatcher) MatchRequestPath(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Path})
+}
+
+func (m *ExecutionMatcher) MatchRequestPaths(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, requestPathsValues(data))
+}
+
+func (m *ExecutionMatcher) MatchRequestPathsElement(ctx context.Context, patternId uint, index int, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, requestPathsElementValues(index, data))
+}
+
+func (m *ExecutionMatcher) MatchRequestQuery(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Query})
+}
+
+func (m *ExecutionMatcher) MatchRequestMethod(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Method})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, bodyValues(data.Request.Body, path))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestGet(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapValues(data.Request.Get))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestGetValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapContentValues(data.Request.Get, path, false))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestPost(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapValues(data.Request.Post))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestPostValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapContentValues(data.Request.Post, path, false))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestHeaders(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapValues(data.Request.Headers))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestHeaderValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapContentValues(data.Request.Headers, path, true))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestCookies(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapValues(data.Request.Cookies))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchRequestCookieValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapContentValues(data.Request.Cookies, path, false))
+}
+
+func (m *ExecutionMatcher) MatchRequestTime(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Time})
+}
+
+func (m *ExecutionMatcher) MatchRequestLength(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Request.Length})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchResponse(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, responseValues(data))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchResponseBody(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, bodyValues(data.Response.Body, path))
+}
+
+func (m *ExecutionMatcher) MatchResponseCode(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Response.Code})
+}
+
+func (m *ExecutionMatcher) MatchResponseSource(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Response.Source})
+}
+
+func (m *ExecutionMatcher) MatchResponseLength(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, []any{data.Response.Length})
+}
+
+func (m *ExecutionMatcher) RecursiveMatchResponseHeaders(ctx context.Context, patternId uint, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapValues(data.Response.Headers))
+}
+
+func (m *ExecutionMatcher) RecursiveMatchResponseHeaderValue(ctx context.Context, patternId uint, path ContentPath, data *HttpData) (bool, error) {
+	return m.matchValues(ctx, patternId, multiMapContentValues(data.Response.Headers, path, true))
+}
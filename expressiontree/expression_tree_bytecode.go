@@ -0,0 +1,271 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var parallelBytecodeError = errors.New("parallel evaluation isn't supported by bytecode backend")
+
+// CompileBackend defines form of compiled predicate, results of backends are identical
+type CompileBackend int
+
+const (
+	// ClosureBackend - each node is compiled into closure calling closures of operands
+	ClosureBackend CompileBackend = iota
+	// BytecodeBackend - expression tree is compiled into flat array of instructions with jumps for short-circuiting,
+	// which is executed by interpreter loop without allocations. Leaves call the same manager methods as closures.
+	BytecodeBackend
+)
+
+type opCode int
+
+const (
+	// result, err = leaves[operand]
+	opLeaf opCode = iota
+	// result, err = value, nil
+	opConst
+	// result = !result if there is no error
+	opNot
+	// if there is error or result == value, AND/OR is decided (result is false on error), jump to target
+	opShortCircuit
+	// if context is done, result, err = false, context error and jump to target
+	opContext
+	// Kleene AND/OR operand: error is remembered in slots[operand],
+	// if result == value, result, err = value, nil and jump to target
+	opKleeneOperand
+	// Kleene AND/OR result: first remembered error (if any) or !value
+	opKleeneResult
+	// slots[operand] = nil
+	opKleeneReset
+	// if evaluation cache has result of keys[operand], result, err = cached result and jump to target
+	opCacheLoad
+	// result, err is stored in evaluation cache by keys[operand]
+	opCacheSave
+)
+
+// instruction of program, operand is index of leaf, slot or key
+type instruction struct {
+	code    opCode
+	operand int
+	value   bool
+	target  int
+	// operand of AND/OR isn't last: if AND/OR isn't decided, context is checked before next operand (as opContext)
+	checkContext bool
+}
+
+type programLeaf struct {
+	node      Node
+	predicate PredicateWithError
+	key       string
+}
+
+// program is expression tree compiled by BytecodeBackend, each node is contiguous block of instructions,
+// result and error of node are set at the end of its block
+type program struct {
+	instructions []instruction
+	leaves       []programLeaf
+	// cache keys of logical nodes
+	keys  []string
+	mode  EvaluationMode
+	stats *SelectivityStats
+	// count of Kleene AND/OR nodes, each of them has slot for first error of its operands
+	slotCount int
+	slots     sync.Pool
+}
+
+func compileProgram(node Node, options CompileOptions) (*program, error) {
+	if options.Parallel != nil {
+		return nil, parallelBytecodeError
+	}
+	compiled := &program{mode: options.Mode, stats: options.Stats}
+	if compileError := compiled.compile(node, options); compileError != nil {
+		return nil, compileError
+	}
+	compiled.slots.New = func() any {
+		slots := make([]error, compiled.slotCount)
+		return &slots
+	}
+	return compiled, nil
+}
+
+func (p *program) emit(code opCode, operand int, value bool) int {
+	p.instructions = append(p.instructions, instruction{code: code, operand: operand, value: value})
+	return len(p.instructions) - 1
+}
+
+// patch sets target of jumps to end of current block
+func (p *program) patch(jumps []int) {
+	for _, jump := range jumps {
+		p.instructions[jump].target = len(p.instructions)
+	}
+}
+
+func (p *program) compile(node Node, options CompileOptions) error {
+	switch current := node.(type) {
+	case *AndNode:
+		return p.compileCached(node, options, func() error {
+			return p.compileLogical(current.Operands, false, options)
+		})
+	case *OrNode:
+		return p.compileCached(node, options, func() error {
+			return p.compileLogical(current.Operands, true, options)
+		})
+	case *NotNode:
+		return p.compileCached(node, options, func() error {
+			if operandError := p.compile(current.Operand, options); operandError != nil {
+				return operandError
+			}
+			p.emit(opNot, 0, false)
+			return nil
+		})
+	case *ConstNode:
+		p.emit(opConst, 0, current.Value)
+		return nil
+	case *CheckNode, *ExistsNode, *MatchNode:
+		leaf, leafError := compileLeaf(node, options)
+		if leafError != nil {
+			return fmt.Errorf("%v: %w", node, leafError)
+		}
+		leaf = options.Metrics.instrumentLeaf(node, leaf)
+		p.leaves = append(p.leaves, programLeaf{node: node, predicate: leaf, key: cacheKey(node, options)})
+		p.emit(opLeaf, len(p.leaves)-1, false)
+		return nil
+	default:
+		return fmt.Errorf("%T: %w", node, unknownExpressionError)
+	}
+}
+
+// compileCached surrounds block of logical node with loading and saving of its result (see createCached)
+func (p *program) compileCached(node Node, options CompileOptions, compileBlock func() error) error {
+	p.keys = append(p.keys, cacheKey(node, options))
+	key := len(p.keys) - 1
+	load := p.emit(opCacheLoad, key, false)
+	if blockError := compileBlock(); blockError != nil {
+		return blockError
+	}
+	p.emit(opCacheSave, key, false)
+	p.instructions[load].target = len(p.instructions)
+	return nil
+}
+
+// compileLogical compiles AND (decisiveResult == false) or OR (decisiveResult == true)
+func (p *program) compileLogical(operands []Node, decisiveResult bool, options CompileOptions) error {
+	if len(operands) <= 1 {
+		return badArgsError
+	}
+	jumps := make([]int, 0, len(operands)+1)
+	slot := -1
+	if p.mode == KleeneLogic {
+		slot = p.slotCount
+		p.slotCount++
+		p.emit(opKleeneReset, slot, false)
+	}
+	jumps = append(jumps, p.emit(opContext, 0, false))
+	for index, operand := range operands {
+		if operandError := p.compile(operand, options); operandError != nil {
+			return operandError
+		}
+		code := opShortCircuit
+		if slot >= 0 {
+			code = opKleeneOperand
+		}
+		jump := p.emit(code, slot, decisiveResult)
+		p.instructions[jump].checkContext = index < len(operands)-1
+		jumps = append(jumps, jump)
+	}
+	if slot >= 0 {
+		p.emit(opKleeneResult, slot, decisiveResult)
+	}
+	p.patch(jumps)
+	return nil
+}
+
+func (p *program) evaluate(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+	var slots []error
+	if p.slotCount > 0 {
+		pooled := p.slots.Get().(*[]error)
+		defer p.slots.Put(pooled)
+		slots = *pooled
+	}
+	cache := EvaluationCacheFromContext(ctx)
+	result := false
+	var err error
+	for index := 0; index < len(p.instructions); {
+		current := &p.instructions[index]
+		index++
+		switch current.code {
+		case opLeaf:
+			leaf := &p.leaves[current.operand]
+			result, err = cache.evaluate(ctx, leaf.key, leaf.predicate, data, manager)
+			if p.stats != nil && err == nil {
+				p.stats.Record(leaf.node, result)
+			}
+			if err != nil && p.mode == ErrorAsFalse {
+				// done context isn't failure of leaf
+				result, err = false, evaluationContextError(ctx)
+			}
+		case opConst:
+			result, err = current.value, nil
+		case opNot:
+			if err != nil {
+				result = false
+			} else {
+				result = !result
+			}
+		case opShortCircuit:
+			if err != nil {
+				result = false
+				index = current.target
+			} else if result == current.value {
+				index = current.target
+			} else if current.checkContext {
+				if contextError := evaluationContextError(ctx); contextError != nil {
+					result, err = false, contextError
+					index = current.target
+				}
+			}
+		case opContext:
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				result, err = false, contextError
+				index = current.target
+			}
+		case opKleeneOperand:
+			if err != nil {
+				if slots[current.operand] == nil {
+					slots[current.operand] = err
+				}
+			} else if result == current.value {
+				index = current.target
+				break
+			}
+			if current.checkContext {
+				if contextError := evaluationContextError(ctx); contextError != nil {
+					result, err = false, contextError
+					index = current.target
+				}
+			}
+		case opKleeneResult:
+			if unknownError := slots[current.operand]; unknownError != nil {
+				result, err = false, unknownError
+				if contextError := evaluationContextError(ctx); contextError != nil {
+					err = contextError
+				}
+			} else {
+				result, err = !current.value, nil
+			}
+		case opKleeneReset:
+			slots[current.operand] = nil
+		case opCacheLoad:
+			if cached, exists := cache.load(p.keys[current.operand], data); exists {
+				result, err = cached.result, cached.err
+				index = current.target
+			}
+		case opCacheSave:
+			cache.save(ctx, p.keys[current.operand], data, result, err)
+		}
+	}
+	return result, err
+}
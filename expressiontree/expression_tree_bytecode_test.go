@@ -0,0 +1,174 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytecodeBackend(t *testing.T) {
+	testCases := []struct {
+		name           string
+		source         string
+		mode           EvaluationMode
+		expectedResult bool
+		expectedError  error
+	}{
+		{name: "leaf", source: "EXISTS(http.options.A)", expectedResult: true},
+		{name: "short-circuit AND", source: "AND(EXISTS(http.options.B), MATCH(http.options.A, 1))", expectedResult: false},
+		{name: "short-circuit OR", source: "OR(EXISTS(http.options.A), MATCH(http.options.A, 1))", expectedResult: true},
+		{name: "nested", source: "AND(NOT(EXISTS(http.options.B)), OR(FALSE(), EXISTS(http.options.A)))", expectedResult: true},
+		{name: "strict error", source: "OR(EXISTS(http.options.B), MATCH(http.options.A, 1))", expectedError: unknownPatternError},
+		{name: "error as false", source: "NOT(MATCH(http.options.A, 1))", mode: ErrorAsFalse, expectedResult: true},
+		{name: "kleene decided", source: "OR(MATCH(http.options.A, 1), EXISTS(http.options.A))", mode: KleeneLogic, expectedResult: true},
+		{name: "kleene unknown", source: "AND(MATCH(http.options.A, 1), EXISTS(http.options.A))", mode: KleeneLogic, expectedError: unknownPatternError},
+	}
+	data := &HttpData{Options: map[string]any{"A": "a"}}
+	manager := CreateExecutionManager(CreatePatternRegistry())
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			expression, expressionError := ParseRuleWithOptions(currentTestCase.source,
+				CompileOptions{Mode: currentTestCase.mode, Backend: BytecodeBackend})
+			assert.NoError(t, expressionError)
+			actualResult, actualError := expression(context.Background(), data, manager)
+			assert.Equal(t, currentTestCase.expectedResult, actualResult)
+			if currentTestCase.expectedError == nil {
+				assert.NoError(t, actualError)
+			} else {
+				assert.ErrorIs(t, actualError, currentTestCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestBytecodeCompileErrors(t *testing.T) {
+	options := CompileOptions{Backend: BytecodeBackend}
+	_, actualError := CompileWithOptions(CreateAndNode(CreateConstNode(true)), options)
+	assert.ErrorIs(t, actualError, badArgsError)
+	_, actualError = CompileWithOptions(CreateExistsNode(CreateDataPathWithMainOnly(RequestTimeKey)), options)
+	assert.ErrorIs(t, actualError, unknownMainPathError)
+	_, actualError = CompileWithOptions(nil, options)
+	assert.ErrorIs(t, actualError, unknownExpressionError)
+	_, actualError = CompileWithOptions(CreateExistsNode(CreateDataPathWithSimpleContent(OptionsKey, "A")),
+		CompileOptions{Backend: BytecodeBackend, Parallel: CreateParallelEvaluator(2, CostModel{}, 0)})
+	assert.ErrorIs(t, actualError, parallelBytecodeError)
+}
+
+// TestBytecodeDifferential compares results, errors, manager calls, cache and selectivity stats of closure and bytecode backends
+func TestBytecodeDifferential(t *testing.T) {
+	optionNames := []string{"A", "B", "C", "D"}
+	random := rand.New(rand.NewSource(11))
+	type evaluation struct {
+		result bool
+		err    error
+		calls  []string
+		hits   uint64
+		misses uint64
+	}
+	httpData := &HttpData{}
+	for iteration := 0; iteration < 300; iteration++ {
+		node := generateOptionsNode(random, optionNames, 4)
+		for _, mode := range []EvaluationMode{StrictErrors, ErrorAsFalse, KleeneLogic} {
+			stats := CreateSelectivityStats()
+			bytecodeStats := CreateSelectivityStats()
+			expression, expressionError := CompileWithOptions(node, CompileOptions{Mode: mode, Stats: stats})
+			bytecode, bytecodeError := CompileWithOptions(node, CompileOptions{Mode: mode, Stats: bytecodeStats, Backend: BytecodeBackend})
+			if !assert.NoError(t, expressionError) || !assert.NoError(t, bytecodeError) {
+				return
+			}
+			for dataIteration := 0; dataIteration < 8; dataIteration++ {
+				values := generateOptionValues(random, optionNames)
+				// evaluation is canceled before some manager call (if cancelAt isn't reached, it isn't canceled)
+				cancelAt := random.Intn(12)
+				withCache := random.Intn(2) == 0
+				evaluate := func(predicate PredicateWithError) evaluation {
+					ctx, cancel := context.WithCancel(context.Background())
+					defer cancel()
+					cache := CreateEvaluationCache()
+					if withCache {
+						ctx = WithEvaluationCache(ctx, cache)
+					}
+					var calls []string
+					call := func(key string) (bool, error) {
+						calls = append(calls, key)
+						if len(calls) == cancelAt {
+							cancel()
+							return false, ctx.Err()
+						}
+						return values[key].result, values[key].err
+					}
+					controller := gomock.NewController(t)
+					defer controller.Finish()
+					mock := NewMockIExecutionManager(controller)
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), gomock.Any(), httpData).DoAndReturn(
+						func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+							return call("exists " + optionName)
+						}).AnyTimes()
+					mock.EXPECT().MatchOption(gomock.Any(), uint(1), gomock.Any(), httpData).DoAndReturn(
+						func(ctx context.Context, patternId uint, optionName string, data *HttpData) (bool, error) {
+							return call("match " + optionName)
+						}).AnyTimes()
+					result, err := predicate(ctx, httpData, mock)
+					return evaluation{result: result, err: err, calls: calls, hits: cache.Hits(), misses: cache.Misses()}
+				}
+				expected := evaluate(expression)
+				actual := evaluate(bytecode)
+				description := fmt.Sprintf("%v, mode %d, values %v, cancel at %d, cache %t", node, mode, values, cancelAt, withCache)
+				assert.Equal(t, expected, actual, description)
+			}
+			assert.Equal(t, stats.counters, bytecodeStats.counters, node.String())
+		}
+	}
+}
+
+func TestBytecodeAllocations(t *testing.T) {
+	for _, mode := range []EvaluationMode{StrictErrors, ErrorAsFalse, KleeneLogic} {
+		expression, expressionError := ParseRuleNode("OR(AND(EXISTS(http.options.A), NOT(EXISTS(http.options.B))), " +
+			"AND(EXISTS(http.options.C), EXISTS(http.options.D)))")
+		assert.NoError(t, expressionError)
+		compiled, compileError := compileProgram(expression, CompileOptions{Mode: mode})
+		assert.NoError(t, compileError)
+		data := &HttpData{Options: map[string]any{"C": "c", "D": "d"}}
+		manager := CreateExecutionManager(nil)
+		ctx := context.Background()
+		allocations := testing.AllocsPerRun(100, func() {
+			if result, _ := compiled.evaluate(ctx, data, manager); !result {
+				t.Fatal("expression is false")
+			}
+		})
+		assert.Zero(t, allocations, "mode %d", mode)
+	}
+}
+
+func BenchmarkBackend(b *testing.B) {
+	// OR of 100 ANDs, only last AND is true
+	operands := make([]Node, 100)
+	for index := range operands {
+		operands[index] = CreateAndNode(
+			CreateNotNode(CreateExistsNode(CreateDataPathWithSimpleContent(OptionsKey, fmt.Sprintf("O%d", index)))),
+			CreateExistsNode(CreateDataPathWithSimpleContent(OptionsKey, fmt.Sprintf("O%d", index+1))))
+	}
+	node := CreateOrNode(operands...)
+	data := &HttpData{Options: map[string]any{"O100": true}}
+	manager := CreateExecutionManager(nil)
+	ctx := context.Background()
+	for _, backend := range []CompileBackend{ClosureBackend, BytecodeBackend} {
+		expression, expressionError := CompileWithOptions(node, CompileOptions{Backend: backend})
+		if expressionError != nil {
+			b.Fatal(expressionError)
+		}
+		b.Run(fmt.Sprintf("backend=%d", backend), func(b *testing.B) {
+			b.ReportAllocs()
+			for iteration := 0; iteration < b.N; iteration++ {
+				if result, _ := expression(ctx, data, manager); !result {
+					b.Fatal("expression is false")
+				}
+			}
+		})
+	}
+}
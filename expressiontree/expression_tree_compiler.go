@@ -0,0 +1,107 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+)
+
+// Compile compiles expression tree into predicate
+func Compile(node Node) (PredicateWithError, error) {
+	return CompileWithOptions(node, CompileOptions{})
+}
+
+// CompileWithOptions compiles expression tree into predicate, errors of leaf nodes are wrapped with source of node.
+// AND/OR nodes must have at least two operands (as in rule language), see EvaluationMode for errors handling.
+// Results of nodes are memoized if evaluation context has cache (see WithEvaluationCache).
+func CompileWithOptions(node Node, options CompileOptions) (PredicateWithError, error) {
+	if options.Backend == BytecodeBackend {
+		compiled, compileError := compileProgram(node, options)
+		if compileError != nil {
+			return nil, compileError
+		}
+		return createEvaluation(compiled.evaluate, options.Timeout), nil
+	}
+	predicate, predicateError := compileNode(node, options)
+	if predicateError != nil {
+		return nil, predicateError
+	}
+	return createEvaluation(predicate, options.Timeout), nil
+}
+
+func compileNode(node Node, options CompileOptions) (PredicateWithError, error) {
+	switch current := node.(type) {
+	case *AndNode:
+		operands, operandsError := compileOperands(current.Operands, options)
+		if operandsError != nil {
+			return nil, operandsError
+		}
+		sequential := createLogicalAndWithMode(options.Mode, operands...)
+		return createCached(node, options.Parallel.createLogical(current.Operands, operands, false, options.Mode, sequential), options), nil
+	case *OrNode:
+		operands, operandsError := compileOperands(current.Operands, options)
+		if operandsError != nil {
+			return nil, operandsError
+		}
+		sequential := createLogicalOrWithMode(options.Mode, operands...)
+		return createCached(node, options.Parallel.createLogical(current.Operands, operands, true, options.Mode, sequential), options), nil
+	case *NotNode:
+		operand, operandError := compileNode(current.Operand, options)
+		if operandError != nil {
+			return nil, operandError
+		}
+		return createCached(node, createLogicalNot(operand), options), nil
+	case *ConstNode:
+		return createConst(current.Value), nil
+	case *CheckNode, *ExistsNode, *MatchNode:
+		leaf, leafError := compileLeaf(node, options)
+		if leafError != nil {
+			return nil, fmt.Errorf("%v: %w", node, leafError)
+		}
+		leaf = options.Metrics.instrumentLeaf(node, leaf)
+		return createLeafWithMode(recordSelectivity(node, createCached(node, leaf, options), options.Stats), options.Mode), nil
+	default:
+		return nil, fmt.Errorf("%T: %w", node, unknownExpressionError)
+	}
+}
+
+func compileOperands(operands []Node, options CompileOptions) ([]PredicateWithError, error) {
+	if len(operands) <= 1 {
+		return nil, badArgsError
+	}
+	result := make([]PredicateWithError, 0, len(operands))
+	for _, operand := range operands {
+		predicate, predicateError := compileNode(operand, options)
+		if predicateError != nil {
+			return nil, predicateError
+		}
+		result = append(result, predicate)
+	}
+	return result, nil
+}
+
+// compileLeaf returns sentinel errors as is, parsers use it for positioned errors
+func compileLeaf(node Node, options CompileOptions) (PredicateWithError, error) {
+	switch current := node.(type) {
+	case *CheckNode:
+		predicate, predicateError := checkPredicate(current, options.Operators)
+		if predicateError != nil {
+			return nil, predicateError
+		}
+		return createCheck(current.Path, predicate)
+	case *ExistsNode:
+		return createExists(current.Path)
+	case *MatchNode:
+		if patternError := options.Patterns.validatePattern(current.PatternId); patternError != nil {
+			return nil, patternError
+		}
+		return createMatch(current.Path, current.PatternId)
+	default:
+		return nil, unknownExpressionError
+	}
+}
+
+func createConst(value bool) PredicateWithError {
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		return value, nil
+	}
+}
@@ -1,6 +1,7 @@
 package expressiontree
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -34,7 +35,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "EXISTS(1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -45,7 +46,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "EXISTS(1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -56,7 +57,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "EXISTS(1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -67,7 +68,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "MATCH(1,666)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -78,7 +79,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "MATCH(1,666)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -89,7 +90,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "MATCH(1,666)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -100,7 +101,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "CHECK(1,0,1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOption(newPredicateMatcher(), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOption(gomock.Any(), newPredicateMatcher(), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -111,7 +112,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "CHECK(1,0,1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOption(newPredicateMatcher(), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOption(gomock.Any(), newPredicateMatcher(), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -122,7 +123,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "CHECK(1,0,1)",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOption(newPredicateMatcher(), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOption(gomock.Any(), newPredicateMatcher(), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -133,7 +134,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "NOT(EXISTS(1))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: false,
@@ -144,7 +145,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "NOT(EXISTS(1))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: true,
@@ -155,7 +156,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "NOT(EXISTS(1))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -166,8 +167,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -178,8 +179,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -190,8 +191,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: false,
@@ -202,8 +203,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -214,8 +215,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -226,8 +227,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, someError),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, someError),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: false,
@@ -238,8 +239,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "AND(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -250,8 +251,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -262,8 +263,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: true,
@@ -274,8 +275,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: true,
@@ -286,8 +287,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, nil),
 				}
 			},
 			expectedResult: false,
@@ -298,8 +299,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, someError),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(true, nil),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, someError),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(true, nil),
 				}
 			},
 			expectedResult: false,
@@ -310,8 +311,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(true, nil),
-					//mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+					//mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: true,
@@ -322,8 +323,8 @@ func TestParseExecuteExpression(t *testing.T) {
 			source: "OR(EXISTS(1),MATCH(1,666))",
 			expectedCalls: func(mock *MockIExecutionManager) []*gomock.Call {
 				return []*gomock.Call{
-					mock.EXPECT().CheckOptionExistence("IDDQD", httpData).Return(false, nil),
-					mock.EXPECT().MatchOption(uint(666), "IDDQD", httpData).Return(false, someError),
+					mock.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(false, nil),
+					mock.EXPECT().MatchOption(gomock.Any(), uint(666), "IDDQD", httpData).Return(false, someError),
 				}
 			},
 			expectedResult: false,
@@ -341,7 +342,7 @@ func TestParseExecuteExpression(t *testing.T) {
 			defer mockController.Finish()
 			executionManager := NewMockIExecutionManager(mockController)
 			gomock.InOrder(currentTestCase.expectedCalls(executionManager)...)
-			actualResult, actualError := expression(httpData, executionManager)
+			actualResult, actualError := expression(context.Background(), httpData, executionManager)
 			assert.Equal(t, currentTestCase.expectedResult, actualResult)
 			assert.Equal(t, currentTestCase.expectedError, actualError)
 		})
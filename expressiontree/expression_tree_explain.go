@@ -0,0 +1,226 @@
+package expressiontree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ExplainNode is result of evaluation of expression tree node, children mirror operands of node.
+// Values are values which predicate of CHECK was called with (manager doesn't show values to MATCH/EXISTS).
+// Literals are literals of MultiLiteralPattern found by true MATCH in the matched value, they are reported
+// only by default manager (see ExecutionManager.WithLiteralRecorder).
+// Skipped node wasn't evaluated because of short-circuiting, its result is meaningless.
+// In ErrorAsFalse mode errors of leaves are kept in trace, but failed leaves are false for parent nodes.
+type ExplainNode struct {
+	Node     Node
+	Result   bool
+	Err      error
+	Values   []any
+	Literals []string
+	Skipped  bool
+	Children []*ExplainNode
+}
+
+type jsonExplainNode struct {
+	Kind     string         `json:"kind"`
+	Source   string         `json:"source"`
+	Result   bool           `json:"result"`
+	Error    string         `json:"error,omitempty"`
+	Values   []any          `json:"values,omitempty"`
+	Literals []string       `json:"literals,omitempty"`
+	Skipped  bool           `json:"skipped,omitempty"`
+	Children []*ExplainNode `json:"children,omitempty"`
+}
+
+// Explain evaluates expression tree as compiled predicate does and returns evaluation trace
+func Explain(ctx context.Context, node Node, data *HttpData, manager IExecutionManager) (*ExplainNode, error) {
+	return ExplainWithOptions(ctx, node, data, manager, CompileOptions{})
+}
+
+// ExplainWithOptions returns error if expression tree can't be compiled, evaluation error is stored in trace
+func ExplainWithOptions(ctx context.Context, node Node, data *HttpData, manager IExecutionManager, options CompileOptions) (*ExplainNode, error) {
+	if _, compileError := CompileWithOptions(node, options); compileError != nil {
+		return nil, compileError
+	}
+	if options.Timeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+		ctx = timeoutCtx
+	}
+	explanation := explainNode(ctx, node, data, manager, options)
+	if explanation.Err != nil {
+		if contextError := evaluationContextError(ctx); contextError != nil {
+			explanation.Err = contextError
+		}
+	}
+	return explanation, nil
+}
+
+func explainNode(ctx context.Context, node Node, data *HttpData, manager IExecutionManager, options CompileOptions) *ExplainNode {
+	explanation := &ExplainNode{Node: node}
+	switch current := node.(type) {
+	case *AndNode:
+		explainOperands(ctx, explanation, current.Operands, false, data, manager, options)
+	case *OrNode:
+		explainOperands(ctx, explanation, current.Operands, true, data, manager, options)
+	case *NotNode:
+		operand := explainNode(ctx, current.Operand, data, manager, options)
+		explanation.Children = []*ExplainNode{operand}
+		if operand.Err != nil && options.Mode != ErrorAsFalse {
+			explanation.Err = operand.Err
+		} else {
+			explanation.Result = !operand.Result
+		}
+	case *ConstNode:
+		explanation.Result = current.Value
+	case *CheckNode:
+		predicate, _ := checkPredicate(current, options.Operators)
+		recordingPredicate := func(value any) (bool, error) {
+			explanation.Values = append(explanation.Values, value)
+			return predicate(value)
+		}
+		check, _ := createCheck(current.Path, recordingPredicate)
+		explanation.Result, explanation.Err = check(ctx, data, manager)
+	case *MatchNode:
+		leaf, _ := compileLeaf(node, options)
+		if defaultManager, isDefault := manager.(*ExecutionManager); isDefault {
+			manager = defaultManager.WithLiteralRecorder(func(patternId uint, literals []string) {
+				explanation.Literals = append(explanation.Literals, literals...)
+			})
+		}
+		explanation.Result, explanation.Err = leaf(ctx, data, manager)
+	default:
+		leaf, _ := compileLeaf(node, options)
+		explanation.Result, explanation.Err = leaf(ctx, data, manager)
+	}
+	if explanation.Err != nil {
+		// result of failed node is false, as result of failed leaf in ErrorAsFalse mode
+		explanation.Result = false
+	}
+	return explanation
+}
+
+// explainOperands evaluates operands of AND (decisiveResult == false) or OR (decisiveResult == true)
+func explainOperands(ctx context.Context, explanation *ExplainNode, operands []Node, decisiveResult bool, data *HttpData, manager IExecutionManager, options CompileOptions) {
+	explanation.Result = !decisiveResult
+	stopped := false
+	for _, operand := range operands {
+		if stopped {
+			explanation.Children = append(explanation.Children, skippedNode(operand))
+			continue
+		}
+		if contextError := evaluationContextError(ctx); contextError != nil {
+			explanation.Result = false
+			explanation.Err = contextError
+			explanation.Children = append(explanation.Children, skippedNode(operand))
+			stopped = true
+			continue
+		}
+		child := explainNode(ctx, operand, data, manager, options)
+		explanation.Children = append(explanation.Children, child)
+		if child.Err != nil && options.Mode != ErrorAsFalse {
+			// KleeneLogic continues evaluation and keeps first error, result may be decided by next operands
+			if explanation.Err == nil {
+				explanation.Err = child.Err
+			}
+			explanation.Result = false
+			stopped = options.Mode != KleeneLogic
+		} else if child.Result == decisiveResult {
+			explanation.Result = decisiveResult
+			explanation.Err = nil
+			stopped = true
+		}
+	}
+}
+
+func skippedNode(node Node) *ExplainNode {
+	explanation := &ExplainNode{Node: node, Skipped: true}
+	switch current := node.(type) {
+	case *AndNode:
+		for _, operand := range current.Operands {
+			explanation.Children = append(explanation.Children, skippedNode(operand))
+		}
+	case *OrNode:
+		for _, operand := range current.Operands {
+			explanation.Children = append(explanation.Children, skippedNode(operand))
+		}
+	case *NotNode:
+		explanation.Children = []*ExplainNode{skippedNode(current.Operand)}
+	}
+	return explanation
+}
+
+// String renders trace as indented text, one node per line:
+// AND -> false
+//
+//	CHECK(http.request.method == "GET") -> false, values: "POST"
+//	EXISTS(http.options.IDDQD) -> skipped
+func (e *ExplainNode) String() string {
+	builder := &strings.Builder{}
+	e.writeText(builder, 0)
+	return builder.String()
+}
+
+func (e *ExplainNode) writeText(builder *strings.Builder, level int) {
+	builder.WriteString(strings.Repeat("  ", level))
+	builder.WriteString(e.title())
+	builder.WriteString(" -> ")
+	switch {
+	case e.Skipped:
+		builder.WriteString("skipped")
+	case e.Err != nil:
+		fmt.Fprintf(builder, "error: %v", e.Err)
+	default:
+		fmt.Fprintf(builder, "%t", e.Result)
+	}
+	if len(e.Values) > 0 {
+		values := make([]string, 0, len(e.Values))
+		for _, value := range e.Values {
+			values = append(values, formatLiteral(value))
+		}
+		builder.WriteString(", values: " + strings.Join(values, ", "))
+	}
+	if len(e.Literals) > 0 {
+		literals := make([]string, 0, len(e.Literals))
+		for _, literal := range e.Literals {
+			literals = append(literals, formatLiteral(literal))
+		}
+		builder.WriteString(", literals: " + strings.Join(literals, ", "))
+	}
+	builder.WriteString("\n")
+	for _, child := range e.Children {
+		child.writeText(builder, level+1)
+	}
+}
+
+// title is source for leaves and operator for AND/OR/NOT (operands are printed as children)
+func (e *ExplainNode) title() string {
+	switch e.Node.(type) {
+	case *AndNode:
+		return "AND"
+	case *OrNode:
+		return "OR"
+	case *NotNode:
+		return "NOT"
+	default:
+		return formatNode(e.Node)
+	}
+}
+
+func (e *ExplainNode) MarshalJSON() ([]byte, error) {
+	source := jsonExplainNode{
+		Kind:     e.Node.nodeKind(),
+		Source:   e.Node.String(),
+		Result:   e.Result,
+		Values:   e.Values,
+		Literals: e.Literals,
+		Skipped:  e.Skipped,
+		Children: e.Children,
+	}
+	if e.Err != nil {
+		source.Error = e.Err.Error()
+	}
+	return json.Marshal(source)
+}
@@ -0,0 +1,140 @@
+package expressiontree
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	node, nodeError := ParseRuleNode(`OR(AND(CHECK(http.request.method == "GET"), EXISTS(http.options.IDDQD)),` +
+		` NOT(CHECK(http.port IN (80, 8080))), MATCH(http.request.body, 1))`)
+	assert.NoError(t, nodeError)
+	explanation, explainError := Explain(context.Background(), node, createSampleHttpData(), CreateExecutionManager(substringPatterns{1: "bob"}))
+	assert.NoError(t, explainError)
+	assert.True(t, explanation.Result)
+	assert.NoError(t, explanation.Err)
+	expectedText := "OR -> true\n" +
+		"  AND -> false\n" +
+		"    CHECK(http.request.method == \"GET\") -> false, values: \"POST\"\n" +
+		"    EXISTS(http.options.IDDQD) -> skipped\n" +
+		"  NOT -> true\n" +
+		"    CHECK(http.port IN (80, 8080)) -> false, values: 443\n" +
+		"  MATCH(http.request.body, 1) -> skipped\n"
+	assert.Equal(t, expectedText, explanation.String())
+	actualJson, jsonError := json.Marshal(explanation)
+	assert.NoError(t, jsonError)
+	expectedJson := `{"kind":"or","source":"OR(AND(CHECK(http.request.method == \"GET\"), EXISTS(http.options.IDDQD)),` +
+		` NOT(CHECK(http.port IN (80, 8080))), MATCH(http.request.body, 1))","result":true,"children":[` +
+		`{"kind":"and","source":"AND(CHECK(http.request.method == \"GET\"), EXISTS(http.options.IDDQD))","result":false,"children":[` +
+		`{"kind":"check","source":"CHECK(http.request.method == \"GET\")","result":false,"values":["POST"]},` +
+		`{"kind":"exists","source":"EXISTS(http.options.IDDQD)","result":false,"skipped":true}]},` +
+		`{"kind":"not","source":"NOT(CHECK(http.port IN (80, 8080)))","result":true,"children":[` +
+		`{"kind":"check","source":"CHECK(http.port IN (80, 8080))","result":false,"values":[443]}]},` +
+		`{"kind":"match","source":"MATCH(http.request.body, 1)","result":false,"skipped":true}]}`
+	assert.JSONEq(t, expectedJson, string(actualJson))
+}
+
+func TestExplainError(t *testing.T) {
+	someError := errors.New("some error")
+	httpData := &HttpData{}
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mock := NewMockIExecutionManager(controller)
+	mock.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(true, nil)
+	mock.EXPECT().CheckOptionExistence(gomock.Any(), "B", httpData).Return(false, someError)
+	node, nodeError := ParseRuleNode("AND(EXISTS(http.options.A), NOT(EXISTS(http.options.B)), EXISTS(http.options.C))")
+	assert.NoError(t, nodeError)
+	explanation, explainError := Explain(context.Background(), node, httpData, mock)
+	assert.NoError(t, explainError)
+	assert.False(t, explanation.Result)
+	assert.Equal(t, someError, explanation.Err)
+	expectedText := "AND -> error: some error\n" +
+		"  EXISTS(http.options.A) -> true\n" +
+		"  NOT -> error: some error\n" +
+		"    EXISTS(http.options.B) -> error: some error\n" +
+		"  EXISTS(http.options.C) -> skipped\n"
+	assert.Equal(t, expectedText, explanation.String())
+	actualJson, jsonError := json.Marshal(explanation.Children[1])
+	assert.NoError(t, jsonError)
+	assert.JSONEq(t, `{"kind":"not","source":"NOT(EXISTS(http.options.B))","result":false,"error":"some error","children":[`+
+		`{"kind":"exists","source":"EXISTS(http.options.B)","result":false,"error":"some error"}]}`, string(actualJson))
+}
+
+func TestExplainCompileError(t *testing.T) {
+	node := CreateMatchNode(CreateDataPathWithMainOnly(RequestBodyKey), 5)
+	_, explainError := ExplainWithOptions(context.Background(), node, &HttpData{}, CreateExecutionManager(nil),
+		CompileOptions{Patterns: CreatePatternRegistry()})
+	assert.Error(t, explainError)
+	_, explainError = Explain(context.Background(), CreateAndNode(CreateConstNode(true)), &HttpData{}, CreateExecutionManager(nil))
+	assert.ErrorIs(t, explainError, badArgsError)
+}
+
+func TestExplainMultiLiterals(t *testing.T) {
+	patterns := CreatePatternRegistry()
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 1, Kind: MultiLiteralPattern, Values: []string{"bob", "alice", "eve"}}))
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 2, Kind: SubstringPattern, Value: "bob"}))
+	node, nodeError := ParseRuleNode("AND(MATCH(http.options.A, 1), MATCH(http.options.A, 2), NOT(MATCH(http.options.B, 1)))")
+	assert.NoError(t, nodeError)
+	httpData := &HttpData{Options: map[string]any{"A": "alice met bob, then bob left", "B": "mallory"}}
+	explanation, explainError := ExplainWithOptions(context.Background(), node, httpData, CreateExecutionManager(patterns), CompileOptions{Patterns: patterns})
+	assert.NoError(t, explainError)
+	assert.True(t, explanation.Result)
+	assert.Equal(t, []string{"alice", "bob"}, explanation.Children[0].Literals)
+	assert.Nil(t, explanation.Children[1].Literals)
+	assert.Nil(t, explanation.Children[2].Children[0].Literals)
+	expectedText := "AND -> true\n" +
+		"  MATCH(http.options.A, 1) -> true, literals: \"alice\", \"bob\"\n" +
+		"  MATCH(http.options.A, 2) -> true\n" +
+		"  NOT -> true\n" +
+		"    MATCH(http.options.B, 1) -> false\n"
+	assert.Equal(t, expectedText, explanation.String())
+	actualJson, jsonError := json.Marshal(explanation.Children[0])
+	assert.NoError(t, jsonError)
+	assert.JSONEq(t, `{"kind":"match","source":"MATCH(http.options.A, 1)","result":true,"literals":["alice","bob"]}`, string(actualJson))
+}
+
+func TestExplainProperty(t *testing.T) {
+	optionNames := []string{"A", "B", "C", "D"}
+	random := rand.New(rand.NewSource(5))
+	httpData := &HttpData{}
+	for iteration := 0; iteration < 300; iteration++ {
+		node := generateOptionsNode(random, optionNames, 3)
+		for _, mode := range []EvaluationMode{StrictErrors, ErrorAsFalse, KleeneLogic} {
+			options := CompileOptions{Mode: mode}
+			expression, expressionError := CompileWithOptions(node, options)
+			if !assert.NoError(t, expressionError) {
+				return
+			}
+			for dataIteration := 0; dataIteration < 4; dataIteration++ {
+				values := generateOptionValues(random, optionNames)
+				controller := gomock.NewController(t)
+				mock := NewMockIExecutionManager(controller)
+				expectOptionValues(mock, values)
+				expectedResult, expectedError := expression(context.Background(), httpData, mock)
+				explanation, explainError := ExplainWithOptions(context.Background(), node, httpData, mock, options)
+				if !assert.NoError(t, explainError) {
+					return
+				}
+				description := fmt.Sprintf("%v, mode %d, values %v", node, mode, values)
+				actualError := explanation.Err
+				if mode == ErrorAsFalse && len(explanation.Children) == 0 {
+					// trace keeps error of failed leaf in ErrorAsFalse mode
+					actualError = nil
+				}
+				assert.Equal(t, expectedError, actualError, description)
+				if expectedError == nil {
+					// result is meaningless if expression fails
+					assert.Equal(t, expectedResult, explanation.Result, description)
+				}
+				controller.Finish()
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package expressiontree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Node is node of expression tree (AST). Parsers produce nodes, Compile turns them into PredicateWithError.
+// String returns canonical source of node in rule language (see rule_parser.go), ParseRuleNode(node.String())
+// returns the same tree.
+type Node interface {
+	String() string
+	nodeKind() string
+}
+
+const (
+	andNodeKind    = "and"
+	orNodeKind     = "or"
+	notNodeKind    = "not"
+	checkNodeKind  = "check"
+	existsNodeKind = "exists"
+	matchNodeKind  = "match"
+	constNodeKind  = "const"
+)
+
+type AndNode struct {
+	Operands []Node
+}
+
+type OrNode struct {
+	Operands []Node
+}
+
+type NotNode struct {
+	Operand Node
+}
+
+// CheckNode - CHECK(path operation argument), argument is literal (nil, bool, number, string)
+// or []any for IN/NOT IN/BETWEEN operations and for arguments of custom operator (OperationCustom)
+type CheckNode struct {
+	Path      DataPath
+	Operation int
+	Argument  any
+	// name of custom operator, it is used with OperationCustom only
+	Operator string
+}
+
+type ExistsNode struct {
+	Path DataPath
+}
+
+type MatchNode struct {
+	Path      DataPath
+	PatternId uint
+}
+
+// ConstNode - TRUE() or FALSE(), it is produced by Optimize mainly
+type ConstNode struct {
+	Value bool
+}
+
+func CreateAndNode(operands ...Node) *AndNode {
+	return &AndNode{Operands: operands}
+}
+
+func CreateOrNode(operands ...Node) *OrNode {
+	return &OrNode{Operands: operands}
+}
+
+func CreateNotNode(operand Node) *NotNode {
+	return &NotNode{Operand: operand}
+}
+
+func CreateCheckNode(path DataPath, operation int, argument any) *CheckNode {
+	return &CheckNode{Path: path, Operation: operation, Argument: argument}
+}
+
+// CreateOperatorCheckNode creates CHECK(path operator(arguments...)) with custom operator
+func CreateOperatorCheckNode(path DataPath, operator string, arguments ...any) *CheckNode {
+	return &CheckNode{Path: path, Operation: OperationCustom, Argument: append([]any{}, arguments...), Operator: operator}
+}
+
+func CreateExistsNode(path DataPath) *ExistsNode {
+	return &ExistsNode{Path: path}
+}
+
+func CreateMatchNode(path DataPath, patternId uint) *MatchNode {
+	return &MatchNode{Path: path, PatternId: patternId}
+}
+
+func CreateConstNode(value bool) *ConstNode {
+	return &ConstNode{Value: value}
+}
+
+func (n *AndNode) nodeKind() string    { return andNodeKind }
+func (n *OrNode) nodeKind() string     { return orNodeKind }
+func (n *NotNode) nodeKind() string    { return notNodeKind }
+func (n *CheckNode) nodeKind() string  { return checkNodeKind }
+func (n *ExistsNode) nodeKind() string { return existsNodeKind }
+func (n *MatchNode) nodeKind() string  { return matchNodeKind }
+func (n *ConstNode) nodeKind() string  { return constNodeKind }
+
+func (n *AndNode) String() string {
+	return "AND(" + formatOperands(n.Operands) + ")"
+}
+
+func (n *OrNode) String() string {
+	return "OR(" + formatOperands(n.Operands) + ")"
+}
+
+func (n *NotNode) String() string {
+	return "NOT(" + formatNode(n.Operand) + ")"
+}
+
+func (n *CheckNode) String() string {
+	builder := &strings.Builder{}
+	builder.WriteString("CHECK(")
+	builder.WriteString(n.Path.String())
+	builder.WriteString(" ")
+	if n.Operation == OperationCustom {
+		builder.WriteString(n.Operator)
+		writeLiteralList(builder, literalList(n.Argument))
+		builder.WriteString(")")
+		return builder.String()
+	}
+	builder.WriteString(operationName(n.Operation))
+	builder.WriteString(" ")
+	switch n.Operation {
+	case OperationIn, OperationNotIn:
+		writeLiteralList(builder, literalList(n.Argument))
+	case OperationBetween:
+		bounds := literalList(n.Argument)
+		if len(bounds) == 2 {
+			builder.WriteString(formatLiteral(bounds[0]) + " AND " + formatLiteral(bounds[1]))
+		} else {
+			builder.WriteString(formatLiteral(n.Argument))
+		}
+	default:
+		builder.WriteString(formatLiteral(n.Argument))
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+func (n *ExistsNode) String() string {
+	return "EXISTS(" + n.Path.String() + ")"
+}
+
+func (n *MatchNode) String() string {
+	return "MATCH(" + n.Path.String() + ", " + strconv.FormatUint(uint64(n.PatternId), 10) + ")"
+}
+
+func (n *ConstNode) String() string {
+	if n.Value {
+		return "TRUE()"
+	}
+	return "FALSE()"
+}
+
+func formatNode(node Node) string {
+	if node == nil {
+		return "<nil>"
+	}
+	return node.String()
+}
+
+func formatOperands(operands []Node) string {
+	parts := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		parts = append(parts, formatNode(operand))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func operationName(operation int) string {
+	if operation == OperationNotIn {
+		return "NOT IN"
+	}
+	for name, value := range ruleOperations {
+		if value == operation {
+			return name
+		}
+	}
+	return "OP(" + strconv.Itoa(operation) + ")"
+}
+
+func writeLiteralList(builder *strings.Builder, items []any) {
+	builder.WriteString("(")
+	for index, item := range items {
+		if index > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(formatLiteral(item))
+	}
+	builder.WriteString(")")
+}
+
+func literalList(argument any) []any {
+	if items, isList := argument.([]any); isList {
+		return items
+	}
+	return nil
+}
+
+// formatLiteral formats literal so that parseRuleWordLiteral/stringValue return value of the same type,
+// floats always have fraction or exponent
+func formatLiteral(value any) string {
+	switch literal := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(literal)
+	case string:
+		return strconv.Quote(literal)
+	case json.Number:
+		return literal.String()
+	case float32:
+		return formatFloatLiteral(float64(literal))
+	case float64:
+		return formatFloatLiteral(literal)
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", value)
+	}
+	if number, numberError := toNumber(value); numberError == nil {
+		return formatFloatLiteral(number)
+	}
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+func formatFloatLiteral(value float64) string {
+	text := strconv.FormatFloat(value, 'g', -1, 64)
+	if math.IsInf(value, 0) || math.IsNaN(value) || strings.ContainsAny(text, ".e") {
+		return text
+	}
+	return text + ".0"
+}
@@ -0,0 +1,244 @@
+package expressiontree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// JSON form of expression tree:
+// {"kind": "and", "operands": [NODE, NODE, ...]}
+// {"kind": "or", "operands": [NODE, NODE, ...]}
+// {"kind": "not", "operand": NODE}
+// {"kind": "check", "path": "http.request.method", "operation": "IN", "argument": ["GET", "HEAD"]}
+// {"kind": "exists", "path": "http.options.IDDQD"}
+// {"kind": "match", "path": "http.request.path", "pattern": 1}
+// {"kind": "const", "value": true}
+// operation is written as in rule language, integer numbers in argument are decoded as int, other numbers as float64
+
+type jsonLogicalNode struct {
+	Kind     string `json:"kind"`
+	Operands []Node `json:"operands"`
+}
+
+type jsonNotNode struct {
+	Kind    string `json:"kind"`
+	Operand Node   `json:"operand"`
+}
+
+// jsonCheckNode - operation is omitted for custom operator
+type jsonCheckNode struct {
+	Kind      string   `json:"kind"`
+	Path      DataPath `json:"path"`
+	Operation string   `json:"operation,omitempty"`
+	Operator  string   `json:"operator,omitempty"`
+	Argument  any      `json:"argument"`
+}
+
+type jsonExistsNode struct {
+	Kind string   `json:"kind"`
+	Path DataPath `json:"path"`
+}
+
+type jsonMatchNode struct {
+	Kind      string   `json:"kind"`
+	Path      DataPath `json:"path"`
+	PatternId uint     `json:"pattern"`
+}
+
+type jsonConstNode struct {
+	Kind  string `json:"kind"`
+	Value bool   `json:"value"`
+}
+
+// jsonNode is used for decoding of any node
+type jsonNode struct {
+	Kind      string            `json:"kind"`
+	Operands  []json.RawMessage `json:"operands"`
+	Operand   json.RawMessage   `json:"operand"`
+	Path      *DataPath         `json:"path"`
+	Operation string            `json:"operation"`
+	Operator  string            `json:"operator"`
+	Argument  json.RawMessage   `json:"argument"`
+	PatternId *uint             `json:"pattern"`
+	Value     *bool             `json:"value"`
+}
+
+func (n *AndNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonLogicalNode{Kind: andNodeKind, Operands: n.Operands})
+}
+
+func (n *OrNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonLogicalNode{Kind: orNodeKind, Operands: n.Operands})
+}
+
+func (n *NotNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonNotNode{Kind: notNodeKind, Operand: n.Operand})
+}
+
+func (n *CheckNode) MarshalJSON() ([]byte, error) {
+	if n.Operation == OperationCustom {
+		return json.Marshal(jsonCheckNode{Kind: checkNodeKind, Path: n.Path, Operator: n.Operator, Argument: literalList(n.Argument)})
+	}
+	return json.Marshal(jsonCheckNode{
+		Kind:      checkNodeKind,
+		Path:      n.Path,
+		Operation: operationName(n.Operation),
+		Argument:  n.Argument,
+	})
+}
+
+func (n *ExistsNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonExistsNode{Kind: existsNodeKind, Path: n.Path})
+}
+
+func (n *MatchNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMatchNode{Kind: matchNodeKind, Path: n.Path, PatternId: n.PatternId})
+}
+
+func (n *ConstNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonConstNode{Kind: constNodeKind, Value: n.Value})
+}
+
+// UnmarshalNode decodes expression tree from JSON
+func UnmarshalNode(data []byte) (Node, error) {
+	source := jsonNode{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if decodeError := decoder.Decode(&source); decodeError != nil {
+		return nil, decodeError
+	}
+	switch source.Kind {
+	case andNodeKind, orNodeKind:
+		operands := make([]Node, 0, len(source.Operands))
+		for _, operandSource := range source.Operands {
+			operand, operandError := UnmarshalNode(operandSource)
+			if operandError != nil {
+				return nil, operandError
+			}
+			operands = append(operands, operand)
+		}
+		if source.Kind == andNodeKind {
+			return CreateAndNode(operands...), nil
+		}
+		return CreateOrNode(operands...), nil
+	case notNodeKind:
+		if len(source.Operand) == 0 {
+			return nil, fmt.Errorf("%s node without operand: %w", source.Kind, badArgsError)
+		}
+		operand, operandError := UnmarshalNode(source.Operand)
+		if operandError != nil {
+			return nil, operandError
+		}
+		return CreateNotNode(operand), nil
+	case checkNodeKind:
+		if source.Path == nil {
+			return nil, fmt.Errorf("%s node without path: %w", source.Kind, badPathError)
+		}
+		if source.Operator != "" {
+			return unmarshalOperatorCheck(source)
+		}
+		operation, operationError := parseOperationName(source.Operation)
+		if operationError != nil {
+			return nil, operationError
+		}
+		argument, argumentError := decodeJsonArgument(source.Argument)
+		if argumentError != nil {
+			return nil, argumentError
+		}
+		return CreateCheckNode(*source.Path, operation, argument), nil
+	case existsNodeKind:
+		if source.Path == nil {
+			return nil, fmt.Errorf("%s node without path: %w", source.Kind, badPathError)
+		}
+		return CreateExistsNode(*source.Path), nil
+	case matchNodeKind:
+		if source.Path == nil {
+			return nil, fmt.Errorf("%s node without path: %w", source.Kind, badPathError)
+		}
+		if source.PatternId == nil {
+			return nil, fmt.Errorf("%s node without pattern: %w", source.Kind, badArgsError)
+		}
+		return CreateMatchNode(*source.Path, *source.PatternId), nil
+	case constNodeKind:
+		if source.Value == nil {
+			return nil, fmt.Errorf("%s node without value: %w", source.Kind, badArgsError)
+		}
+		return CreateConstNode(*source.Value), nil
+	default:
+		return nil, fmt.Errorf("node kind %q: %w", source.Kind, unknownExpressionError)
+	}
+}
+
+// unmarshalOperatorCheck decodes check with custom operator, argument is list of literals (it may be omitted)
+func unmarshalOperatorCheck(source jsonNode) (Node, error) {
+	if source.Operation != "" {
+		return nil, fmt.Errorf("%s node with operation and operator: %w", source.Kind, badArgsError)
+	}
+	argument, argumentError := decodeJsonArgument(source.Argument)
+	if argumentError != nil {
+		return nil, argumentError
+	}
+	arguments, isList := argument.([]any)
+	if !isList && argument != nil {
+		return nil, fmt.Errorf("operator %q: %w", source.Operator, badArgumentTypeError)
+	}
+	return CreateOperatorCheckNode(*source.Path, source.Operator, arguments...), nil
+}
+
+func parseOperationName(name string) (int, error) {
+	if name == "NOT IN" {
+		return OperationNotIn, nil
+	}
+	operation, exists := ruleOperations[name]
+	if !exists {
+		return 0, fmt.Errorf("operation %q: %w", name, unsupportedOperationError)
+	}
+	return operation, nil
+}
+
+func decodeJsonArgument(source json.RawMessage) (any, error) {
+	if len(source) == 0 {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(source))
+	decoder.UseNumber()
+	var argument any
+	if decodeError := decoder.Decode(&argument); decodeError != nil {
+		return nil, decodeError
+	}
+	return normalizeJsonLiteral(argument)
+}
+
+func normalizeJsonLiteral(value any) (any, error) {
+	switch literal := value.(type) {
+	case nil, bool, string, int:
+		// int is decoded by YAML
+		return literal, nil
+	case float64:
+		// float64 is decoded by YAML, it accepts .nan and .inf
+		if math.IsNaN(literal) || math.IsInf(literal, 0) {
+			return nil, badLiteralError
+		}
+		return literal, nil
+	case json.Number:
+		if intValue, intError := strconv.Atoi(literal.String()); intError == nil {
+			return intValue, nil
+		}
+		return literal.Float64()
+	case []any:
+		items := make([]any, 0, len(literal))
+		for _, item := range literal {
+			normalizedItem, itemError := normalizeJsonLiteral(item)
+			if itemError != nil {
+				return nil, itemError
+			}
+			items = append(items, normalizedItem)
+		}
+		return items, nil
+	default:
+		return nil, badLiteralError
+	}
+}
@@ -0,0 +1,125 @@
+package expressiontree
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeString(t *testing.T) {
+	testCases := []struct {
+		source         string
+		expectedSource string
+	}{
+		{source: "EXISTS(http.options.IDDQD)", expectedSource: "EXISTS(http.options.IDDQD)"},
+		{source: "MATCH(http.request.headers.IDKFA,666)", expectedSource: "MATCH(http.request.headers.IDKFA, 666)"},
+		{source: `CHECK(http.request.headers.X-Token=="a\"b")`, expectedSource: `CHECK(http.request.headers.X-Token == "a\"b")`},
+		{source: "CHECK(http.response.code >= 500)", expectedSource: "CHECK(http.response.code >= 500)"},
+		{source: "CHECK(http.client.geoip.lat < 1.0)", expectedSource: "CHECK(http.client.geoip.lat < 1.0)"},
+		{source: "CHECK(http.client.geoip.lat < -12.5e1)", expectedSource: "CHECK(http.client.geoip.lat < -125.0)"},
+		{source: "CHECK(http.options.IDCLIP != null)", expectedSource: "CHECK(http.options.IDCLIP != null)"},
+		{
+			source:         `CHECK(http.request.method NOT   IN ("GET","HEAD"))`,
+			expectedSource: `CHECK(http.request.method NOT IN ("GET", "HEAD"))`,
+		},
+		{source: "CHECK(http.response.code BETWEEN 500 AND 599)", expectedSource: "CHECK(http.response.code BETWEEN 500 AND 599)"},
+		{source: `EXISTS(http.request.headers."X.Forwarded.For")`, expectedSource: `EXISTS(http.request.headers."X.Forwarded.For")`},
+		{source: `EXISTS(http.request.headers."a//b")`, expectedSource: `EXISTS(http.request.headers."a//b")`},
+		{source: `MATCH(http.request.body."/*x*/".a/b, 1)`, expectedSource: `MATCH(http.request.body."/*x*/".a/b, 1)`},
+		{
+			source:         "OR(AND(EXISTS(http.options.IDDQD),NOT(MATCH(http.host,1))),\n CHECK(http.port == 80))",
+			expectedSource: "OR(AND(EXISTS(http.options.IDDQD), NOT(MATCH(http.host, 1))), CHECK(http.port == 80))",
+		},
+	}
+	for _, testCase := range testCases {
+		node, nodeError := ParseRuleNode(testCase.source)
+		assert.NoError(t, nodeError, testCase.source)
+		assert.Equal(t, testCase.expectedSource, node.String())
+		reparsedNode, reparsedError := ParseRuleNode(node.String())
+		assert.NoError(t, reparsedError, testCase.source)
+		assert.Equal(t, node, reparsedNode)
+	}
+}
+
+func TestNodeJson(t *testing.T) {
+	source := `OR(AND(EXISTS(http.options.IDDQD), NOT(MATCH(http.request.body.user."a.b", 1))),` +
+		` CHECK(http.request.method IN ("GET", "HEAD")), CHECK(http.client.geoip.lat BETWEEN 1.5 AND 2),` +
+		` CHECK(http.options.IDCLIP == null))`
+	node, nodeError := ParseRuleNode(source)
+	assert.NoError(t, nodeError)
+	data, marshalError := json.Marshal(node)
+	assert.NoError(t, marshalError)
+	unmarshalledNode, unmarshalError := UnmarshalNode(data)
+	assert.NoError(t, unmarshalError)
+	assert.Equal(t, node, unmarshalledNode)
+	assert.Equal(t, source, unmarshalledNode.String())
+
+	checkData, checkMarshalError := json.Marshal(CreateCheckNode(CreateDataPathWithMainOnly(HttpDataPortKey), OperationNotIn, []any{80, 443}))
+	assert.NoError(t, checkMarshalError)
+	assert.JSONEq(t, `{"kind": "check", "path": "http.port", "operation": "NOT IN", "argument": [80, 443]}`, string(checkData))
+
+	testCases := []struct {
+		source        string
+		expectedError error
+	}{
+		{source: `{"kind": "xor", "operands": []}`, expectedError: unknownExpressionError},
+		{source: `{"kind": "not"}`, expectedError: badArgsError},
+		{source: `{"kind": "exists"}`, expectedError: badPathError},
+		{source: `{"kind": "exists", "path": "web.host"}`, expectedError: unknownMainPathError},
+		{source: `{"kind": "match", "path": "http.host"}`, expectedError: badArgsError},
+		{source: `{"kind": "check", "path": "http.port", "operation": "~", "argument": 1}`, expectedError: unsupportedOperationError},
+		{source: `{"kind": "check", "path": "http.port", "operation": "==", "argument": {"a": 1}}`, expectedError: badLiteralError},
+	}
+	for _, testCase := range testCases {
+		_, actualError := UnmarshalNode([]byte(testCase.source))
+		assert.ErrorIs(t, actualError, testCase.expectedError, testCase.source)
+	}
+}
+
+func TestCompileNode(t *testing.T) {
+	manager := CreateExecutionManager(substringPatterns{1: "bob"})
+	httpData := createSampleHttpData()
+	node := CreateAndNode(
+		CreateCheckNode(CreateDataPathWithMainOnly(RequestMethodKey), OperationIn, []any{"POST", "PUT"}),
+		CreateOrNode(
+			CreateNotNode(CreateExistsNode(CreateDataPathWithSimpleContent(OptionsKey, "IDDQD"))),
+			CreateMatchNode(CreateDataPath(RequestBodyKey, CreateContentPath("user.name", []string{"user", "name"})), 1),
+		),
+	)
+	expression, expressionError := Compile(node)
+	assert.NoError(t, expressionError)
+	actualResult, actualError := expression(context.Background(), httpData, manager)
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+
+	testCases := []struct {
+		node          Node
+		expectedError error
+	}{
+		{node: CreateAndNode(CreateExistsNode(CreateDataPathWithSimpleContent(OptionsKey, "IDDQD"))), expectedError: badArgsError},
+		{node: CreateOrNode(), expectedError: badArgsError},
+		{node: CreateNotNode(nil), expectedError: unknownExpressionError},
+		{node: CreateExistsNode(CreateDataPathWithMainOnly(HttpDataHostKey)), expectedError: unknownMainPathError},
+		{node: CreateCheckNode(CreateDataPathWithMainOnly(HttpDataPortKey), OperationContains, 1), expectedError: badArgumentTypeError},
+	}
+	for _, testCase := range testCases {
+		_, actualCompileError := Compile(testCase.node)
+		assert.ErrorIs(t, actualCompileError, testCase.expectedError)
+	}
+	registry := CreatePatternRegistry()
+	_, patternError := CompileWithOptions(CreateMatchNode(CreateDataPathWithMainOnly(HttpDataHostKey), 1), CompileOptions{Patterns: registry})
+	assert.ErrorIs(t, patternError, unknownPatternError)
+}
+
+func TestParseExpressionTreeNode(t *testing.T) {
+	storage := &parseStorage{
+		knownPath:      []DataPath{CreateDataPathWithSimpleContent(OptionsKey, "IDDQD"), CreateDataPathWithMainOnly(RequestMethodKey)},
+		checkArguments: []any{"GET", []any{"GET", "HEAD"}},
+	}
+	node, nodeError := parseExpressionTreeNode("AND(EXISTS(0),OR(CHECK(1,0,0),CHECK(1,7,1)),NOT(MATCH(1,5)))", storage)
+	assert.NoError(t, nodeError)
+	assert.Equal(t, `AND(EXISTS(http.options.IDDQD), OR(CHECK(http.request.method == "GET"),`+
+		` CHECK(http.request.method NOT IN ("GET", "HEAD"))), NOT(MATCH(http.request.method, 5)))`, node.String())
+}
@@ -0,0 +1,125 @@
+package expressiontree
+
+// Optimize simplifies expression tree, nodes are compared by canonical source (String()):
+//   - nested AND/OR are flattened: AND(AND(a,b),c) -> AND(a,b,c)
+//   - duplicated operands are removed, the first occurrence is kept: AND(a,b,a) -> AND(a,b)
+//   - double negation is eliminated: NOT(NOT(a)) -> a
+//   - constants are folded: AND(a,TRUE()) -> a, AND(a,FALSE()) -> FALSE(), NOT(TRUE()) -> FALSE()
+//   - absorption laws are applied: AND(a,OR(a,b)) -> a, OR(a,AND(a,b)) -> a
+//     (only if absorbing operand precedes absorbed one, AND(OR(a,b),a) isn't changed)
+//   - complements are folded: AND(a,NOT(a)) -> FALSE(), OR(a,NOT(a)) -> TRUE()
+//
+// Every AND/OR of result has at least two operands, so result can be compiled.
+// Error policy: if original expression returns result without error, optimized one returns the same result
+// without error (order of evaluation of remaining operands is kept).
+// Optimized expression may skip failing operands: e.g. AND(CHECK(...),FALSE()) returns false without evaluation
+// of CHECK, while original expression returns error of CHECK.
+func Optimize(node Node) Node {
+	switch current := node.(type) {
+	case *AndNode:
+		return optimizeLogical(current.Operands, true)
+	case *OrNode:
+		return optimizeLogical(current.Operands, false)
+	case *NotNode:
+		operand := Optimize(current.Operand)
+		switch inner := operand.(type) {
+		case *NotNode:
+			return inner.Operand
+		case *ConstNode:
+			return CreateConstNode(!inner.Value)
+		}
+		return CreateNotNode(operand)
+	default:
+		return node
+	}
+}
+
+// optimizeLogical optimizes operands of AND (isAnd == true) or OR (isAnd == false)
+// TRUE() is identity for AND and FALSE() is absorbing element for AND, vice versa for OR
+func optimizeLogical(operands []Node, isAnd bool) Node {
+	result := make([]Node, 0, len(operands))
+	keys := make(map[string]struct{}, len(operands))
+	for _, operand := range operands {
+		for _, item := range flattenOperands(Optimize(operand), isAnd) {
+			if constNode, isConst := item.(*ConstNode); isConst {
+				if constNode.Value == isAnd {
+					continue
+				}
+				return CreateConstNode(!isAnd)
+			}
+			key := formatNode(item)
+			if _, exists := keys[key]; exists {
+				continue
+			}
+			keys[key] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	for _, item := range result {
+		if notNode, isNot := item.(*NotNode); isNot {
+			if _, exists := keys[formatNode(notNode.Operand)]; exists {
+				return CreateConstNode(!isAnd)
+			}
+		}
+	}
+	// absorbed operand is removed only if absorbing one precedes it, otherwise operands between them would be
+	// evaluated in cases when absorbed operand decides result: OR(AND(a,b),c,b) returns true without evaluation of c
+	absorbed := make([]Node, 0, len(result))
+	precedingKeys := make(map[string]struct{}, len(result))
+	for _, item := range result {
+		if !isAbsorbed(item, precedingKeys, isAnd) {
+			absorbed = append(absorbed, item)
+		}
+		precedingKeys[formatNode(item)] = struct{}{}
+	}
+	switch len(absorbed) {
+	case 0:
+		return CreateConstNode(isAnd)
+	case 1:
+		return absorbed[0]
+	}
+	if isAnd {
+		return CreateAndNode(absorbed...)
+	}
+	return CreateOrNode(absorbed...)
+}
+
+// flattenOperands returns operands of node if node has the same kind as parent, otherwise returns node itself
+func flattenOperands(node Node, isAnd bool) []Node {
+	switch current := node.(type) {
+	case *AndNode:
+		if isAnd {
+			return current.Operands
+		}
+	case *OrNode:
+		if !isAnd {
+			return current.Operands
+		}
+	}
+	return []Node{node}
+}
+
+// isAbsorbed checks that item of AND is OR with one of operands from AND (or vice versa)
+func isAbsorbed(item Node, parentKeys map[string]struct{}, isAnd bool) bool {
+	var operands []Node
+	switch current := item.(type) {
+	case *OrNode:
+		if !isAnd {
+			return false
+		}
+		operands = current.Operands
+	case *AndNode:
+		if isAnd {
+			return false
+		}
+		operands = current.Operands
+	default:
+		return false
+	}
+	for _, operand := range operands {
+		if _, exists := parentKeys[formatNode(operand)]; exists {
+			return true
+		}
+	}
+	return false
+}
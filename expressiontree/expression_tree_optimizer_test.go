@@ -0,0 +1,158 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimize(t *testing.T) {
+	testCases := []struct {
+		source         string
+		expectedSource string
+	}{
+		{source: "AND(AND(EXISTS(http.options.A), EXISTS(http.options.B)), AND(EXISTS(http.options.B), EXISTS(http.options.C)))",
+			expectedSource: "AND(EXISTS(http.options.A), EXISTS(http.options.B), EXISTS(http.options.C))"},
+		{source: "OR(EXISTS(http.options.A), OR(EXISTS(http.options.B), OR(EXISTS(http.options.A), EXISTS(http.options.C))))",
+			expectedSource: "OR(EXISTS(http.options.A), EXISTS(http.options.B), EXISTS(http.options.C))"},
+		{source: "NOT(NOT(EXISTS(http.options.A)))", expectedSource: "EXISTS(http.options.A)"},
+		{source: "NOT(NOT(NOT(EXISTS(http.options.A))))", expectedSource: "NOT(EXISTS(http.options.A))"},
+		{source: "AND(EXISTS(http.options.A), TRUE())", expectedSource: "EXISTS(http.options.A)"},
+		{source: "AND(EXISTS(http.options.A), FALSE())", expectedSource: "FALSE()"},
+		{source: "OR(EXISTS(http.options.A), NOT(TRUE()))", expectedSource: "EXISTS(http.options.A)"},
+		{source: "OR(EXISTS(http.options.A), TRUE())", expectedSource: "TRUE()"},
+		{source: "AND(TRUE(), TRUE())", expectedSource: "TRUE()"},
+		{source: "OR(FALSE(), FALSE())", expectedSource: "FALSE()"},
+		{source: "AND(EXISTS(http.options.A), OR(EXISTS(http.options.B), EXISTS(http.options.A)))", expectedSource: "EXISTS(http.options.A)"},
+		{source: "OR(EXISTS(http.options.A), AND(EXISTS(http.options.B), EXISTS(http.options.A)))", expectedSource: "EXISTS(http.options.A)"},
+		{source: "OR(AND(EXISTS(http.options.B), EXISTS(http.options.A)), EXISTS(http.options.C), EXISTS(http.options.A))",
+			expectedSource: "OR(AND(EXISTS(http.options.B), EXISTS(http.options.A)), EXISTS(http.options.C), EXISTS(http.options.A))"},
+		{source: "AND(EXISTS(http.options.A), NOT(EXISTS(http.options.A)))", expectedSource: "FALSE()"},
+		{source: "OR(NOT(EXISTS(http.options.A)), EXISTS(http.options.B), EXISTS(http.options.A))", expectedSource: "TRUE()"},
+		{source: "AND(EXISTS(http.options.A), EXISTS(http.options.A))", expectedSource: "EXISTS(http.options.A)"},
+		{source: "NOT(AND(EXISTS(http.options.A), OR(EXISTS(http.options.A), EXISTS(http.options.B))))",
+			expectedSource: "NOT(EXISTS(http.options.A))"},
+		{source: `OR(CHECK(http.request.method == "GET"), AND(CHECK(http.port == 80), NOT(NOT(CHECK(http.request.method == "GET")))))`,
+			expectedSource: `CHECK(http.request.method == "GET")`},
+	}
+	for _, testCase := range testCases {
+		node, nodeError := ParseRuleNode(testCase.source)
+		assert.NoError(t, nodeError, testCase.source)
+		optimizedNode := Optimize(node)
+		assert.Equal(t, testCase.expectedSource, optimizedNode.String(), testCase.source)
+		assert.Equal(t, optimizedNode.String(), Optimize(optimizedNode).String(), testCase.source)
+	}
+}
+
+// generateNode generates random tree with NOT/AND/OR nodes, leaves are generated by generateLeaf
+func generateNode(random *rand.Rand, generateLeaf func() Node, depth int) Node {
+	choice := random.Intn(12)
+	if depth == 0 || choice < 4 {
+		return generateLeaf()
+	}
+	operands := make([]Node, 2+random.Intn(3))
+	for index := range operands {
+		operands[index] = generateNode(random, generateLeaf, depth-1)
+	}
+	switch choice {
+	case 4, 5:
+		return CreateNotNode(operands[0])
+	case 6, 7, 8:
+		return CreateAndNode(operands...)
+	default:
+		return CreateOrNode(operands...)
+	}
+}
+
+// generateOptionsNode generates random tree with leaves EXISTS(http.options.NAME), MATCH(http.options.NAME, 1),
+// TRUE() and FALSE(), names of options are chosen from names
+func generateOptionsNode(random *rand.Rand, names []string, depth int) Node {
+	return generateNode(random, func() Node {
+		choice := random.Intn(8)
+		path := CreateDataPathWithSimpleContent(OptionsKey, names[random.Intn(len(names))])
+		switch {
+		case choice == 0:
+			return CreateConstNode(random.Intn(2) == 0)
+		case choice%2 == 0:
+			return CreateMatchNode(path, 1)
+		default:
+			return CreateExistsNode(path)
+		}
+	}, depth)
+}
+
+// optionValue is result of EXISTS or MATCH of option
+type optionValue struct {
+	result bool
+	err    error
+	// delay is used by optionValuesManager
+	delay time.Duration
+}
+
+// generateOptionValues generates values of EXISTS ("exists NAME") and MATCH ("match NAME") of options,
+// quarter of them fail with distinct errors
+func generateOptionValues(random *rand.Rand, names []string) map[string]optionValue {
+	values := map[string]optionValue{}
+	for _, name := range names {
+		for _, kind := range []string{"exists ", "match "} {
+			value := optionValue{result: random.Intn(2) == 0}
+			if random.Intn(4) == 0 {
+				value.err = errors.New(kind + name)
+			}
+			values[kind+name] = value
+		}
+	}
+	return values
+}
+
+// expectOptionValues makes mock return values of EXISTS and MATCH of options
+func expectOptionValues(mock *MockIExecutionManager, values map[string]optionValue) {
+	mock.EXPECT().CheckOptionExistence(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+		return values["exists "+optionName].result, values["exists "+optionName].err
+	}).AnyTimes()
+	mock.EXPECT().MatchOption(gomock.Any(), uint(1), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, patternId uint, optionName string, data *HttpData) (bool, error) {
+		return values["match "+optionName].result, values["match "+optionName].err
+	}).AnyTimes()
+}
+
+func TestOptimizeProperty(t *testing.T) {
+	optionNames := []string{"A", "B", "C", "D"}
+	random := rand.New(rand.NewSource(42))
+	httpData := &HttpData{}
+	for iteration := 0; iteration < 500; iteration++ {
+		node := generateOptionsNode(random, optionNames, 4)
+		optimizedNode := Optimize(node)
+		expression, expressionError := Compile(node)
+		optimizedExpression, optimizedExpressionError := Compile(optimizedNode)
+		if !assert.NoError(t, expressionError, node.String()) || !assert.NoError(t, optimizedExpressionError, optimizedNode.String()) {
+			return
+		}
+		for dataIteration := 0; dataIteration < 8; dataIteration++ {
+			values := generateOptionValues(random, optionNames)
+			controller := gomock.NewController(t)
+			mock := NewMockIExecutionManager(controller)
+			expectOptionValues(mock, values)
+			actualResult, actualError := expression(context.Background(), httpData, mock)
+			optimizedResult, optimizedError := optimizedExpression(context.Background(), httpData, mock)
+			description := fmt.Sprintf("%v -> %v, values %v", node, optimizedNode, values)
+			if actualError == nil {
+				assert.NoError(t, optimizedError, description)
+				assert.Equal(t, actualResult, optimizedResult, description)
+			} else if optimizedError != nil {
+				// optimized tree may fail on another leaf (leaves are deduplicated and folded), but not with new error
+				leafErrors := []error{}
+				for _, value := range values {
+					leafErrors = append(leafErrors, value.err)
+				}
+				assert.Contains(t, leafErrors, optimizedError, description)
+			}
+			controller.Finish()
+		}
+	}
+}
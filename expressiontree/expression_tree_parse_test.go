@@ -1,6 +1,8 @@
 package expressiontree
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +19,8 @@ func TestParseExpression(t *testing.T) {
 		checkArguments: []any{
 			"",
 			"IDCLIP",
+			[]any{"IDCLIP", "IDSPISPOPD"},
+			666,
 		},
 	}
 	testCases := []struct {
@@ -78,6 +82,26 @@ func TestParseExpression(t *testing.T) {
 			source:        "OR(AND(EXISTS(1),NOT(MATCH(1,666))),AND(NOT(EXISTS(2)),MATCH(2,777)))",
 			expectedError: nil,
 		},
+		{
+			name:          "whitespace between tokens",
+			source:        " AND ( EXISTS ( 1 ) ,\n\tMATCH( 1 , 666 ) ) ",
+			expectedError: nil,
+		},
+		{
+			name:          "comments between tokens",
+			source:        "OR( // options\n EXISTS(1 /* IDDQD */), /* ∀ */ CHECK(1,0,1)) // end",
+			expectedError: nil,
+		},
+		{
+			name:          "unterminated comment",
+			source:        "AND(EXISTS(1), /* EXISTS(2)) ",
+			expectedError: parseError,
+		},
+		{
+			name:          "space inside integer",
+			source:        "CHECK(1,0 0,1)",
+			expectedError: parseError,
+		},
 		{
 			name:          "EXISTS(http.request.time)",
 			source:        "EXISTS(3)",
@@ -106,6 +130,26 @@ func TestParseExpression(t *testing.T) {
 		{
 			name:          `CHECK(http.options.IDDQD<"IDCLIP")`,
 			source:        "CHECK(1,2,1)",
+			expectedError: nil,
+		},
+		{
+			name:          `CHECK(http.options.IDDQD IN ("IDCLIP","IDSPISPOPD"))`,
+			source:        "CHECK(1,6,2)",
+			expectedError: nil,
+		},
+		{
+			name:          `CHECK(http.options.IDDQD IN "IDCLIP")`,
+			source:        "CHECK(1,6,1)",
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          `CHECK(http.options.IDDQD CONTAINS 666)`,
+			source:        "CHECK(1,8,3)",
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          `CHECK(http.options.IDDQD??"IDCLIP")`,
+			source:        "CHECK(1,100,1)",
 			expectedError: unsupportedOperationError,
 		},
 		{
@@ -195,6 +239,48 @@ func checkParse(t *testing.T, source string, storage *parseStorage, expectedErro
 		assert.NoError(t, actualError)
 	} else {
 		assert.Nil(t, result)
-		assert.Equal(t, expectedError, actualError)
+		assert.ErrorIs(t, actualError, expectedError)
+	}
+}
+
+// generateIndexedSource returns OR of ANDs with leafCount leaves, separated by whitespace and comments
+func generateIndexedSource(leafCount int) string {
+	leaves := make([]string, leafCount)
+	for index := range leaves {
+		switch index % 3 {
+		case 0:
+			leaves[index] = "CHECK(1, 0, 1)"
+		case 1:
+			leaves[index] = "EXISTS(2)"
+		default:
+			leaves[index] = fmt.Sprintf("MATCH(1, %d)", index)
+		}
+	}
+	groups := make([]string, 0, leafCount/4)
+	for start := 0; start < leafCount; start += 4 {
+		groups = append(groups, "AND(\n  "+strings.Join(leaves[start:start+4], ", /* leaf */\n  ")+")")
+	}
+	return "OR( // generated\n" + strings.Join(groups, ",\n") + ")"
+}
+
+func BenchmarkParseExpressionTree(b *testing.B) {
+	storage := &parseStorage{
+		knownPath: []DataPath{
+			CreateDataPathWithMainOnly(HttpDataKey),
+			CreateDataPathWithSimpleContent(OptionsKey, "IDDQD"),
+			CreateDataPathWithSimpleContent(RequestHeadersKey, "IDKFA"),
+		},
+		checkArguments: []any{"", "IDCLIP"},
+	}
+	for _, leafCount := range []int{100, 1000, 10000} {
+		source := generateIndexedSource(leafCount)
+		b.Run(fmt.Sprintf("leaves=%d", leafCount), func(b *testing.B) {
+			b.SetBytes(int64(len(source)))
+			for iteration := 0; iteration < b.N; iteration++ {
+				if _, parseError := parseExpressionTreeNode(source, storage); parseError != nil {
+					b.Fatal(parseError)
+				}
+			}
+		})
 	}
 }
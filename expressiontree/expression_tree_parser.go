@@ -1,7 +1,9 @@
 package expressiontree
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -11,10 +13,23 @@ import (
 // NOT(COND)
 // COND: CHECK(PATH,OP,ARG) | EXISTS(PATH) | MATCH(PATH,PATTERN)
 // OP: INT (in table), PATH: INT (in table), ARG: INT (in table), PATTERN: INT (in table)
+// ARG for IN/NOT IN/BETWEEN operations is []any
 
 const (
-	OperationEqual    = 0
-	OperationNotEqual = 1
+	OperationEqual          = 0
+	OperationNotEqual       = 1
+	OperationLess           = 2
+	OperationLessOrEqual    = 3
+	OperationGreater        = 4
+	OperationGreaterOrEqual = 5
+	OperationIn             = 6
+	OperationNotIn          = 7
+	OperationContains       = 8
+	OperationStartsWith     = 9
+	OperationEndsWith       = 10
+	OperationBetween        = 11
+	// OperationCustom - operator of OperatorRegistry, it isn't supported by indexed expressions
+	OperationCustom = 12
 )
 
 var parseError = errors.New("parse error")
@@ -24,218 +39,341 @@ var unsupportedOperationError = errors.New("unsupported operation error")
 var unknownMainPathError = errors.New("unknown main path")
 var badContentPathError = errors.New("bad content path")
 var badRequestPathIndexError = errors.New("bad request path index")
+var indexOutOfRangeError = errors.New("index out of range")
 
-type PredicateWithError func(data *HttpData, manager IExecutionManager) (bool, error)
+// PredicateWithError is compiled expression, evaluation is stopped with error when ctx is done
+type PredicateWithError func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error)
 
-type sourceReader struct {
-	//position int
-	source string
+// indexedParser parses expression with indexes of paths, operations, arguments and patterns,
+// tokens are read by rule lexer (whitespace and comments are allowed between tokens)
+type indexedParser struct {
+	lexer   *ruleLexer
+	current token
+	storage *parseStorage
 }
 
-func (r *sourceReader) readTo(end string) (string, error) {
-	index := strings.IndexAny(r.source, end)
-	if index == -1 {
-		return "", parseError
+// indexedArguments are integer arguments of CHECK, EXISTS or MATCH
+type indexedArguments struct {
+	values []int
+	tokens []token
+	// source of arguments (without parentheses) and its offset, it is used for errors of whole expression
+	text   string
+	offset int
+}
+
+func newIndexedParser(source string, storage *parseStorage) (*indexedParser, error) {
+	parser := &indexedParser{lexer: newRuleLexer(source), storage: storage}
+	if advanceError := parser.advance(); advanceError != nil {
+		return nil, advanceError
 	}
-	result := string([]rune(r.source)[0 : index+1])
-	rest := string([]rune(r.source)[index+1:])
-	//r.position = index + 1
-	r.source = rest
-	return result, nil
+	return parser, nil
 }
 
-func (r *sourceReader) readCurrent() (string, error) {
-	if len(r.source) == 0 {
-		return "", parseError
+func (p *indexedParser) advance() error {
+	current, nextError := p.lexer.next()
+	if nextError != nil {
+		return nextError
 	}
-	result := string([]rune(r.source)[0])
-	rest := string([]rune(r.source)[1:])
-	r.source = rest
-	return result, nil
+	p.current = current
+	return nil
 }
 
-func (r *sourceReader) isEmpty() bool {
-	return len(r.source) == 0
+// expect checks kind of current token and advances, expected describes token in error (kind name by default)
+func (p *indexedParser) expect(kind tokenKind, expected ...string) (token, error) {
+	current := p.current
+	if current.kind != kind {
+		if len(expected) == 0 {
+			expected = []string{kind.String()}
+		}
+		return token{}, p.errorAt(current.offset, current.text, expected, parseError)
+	}
+	if advanceError := p.advance(); advanceError != nil {
+		return token{}, advanceError
+	}
+	return current, nil
 }
 
-func newSourceReader(source string) *sourceReader {
-	return &sourceReader{source}
+func (p *indexedParser) errorAt(offset int, text string, expected []string, err error) error {
+	return newParseError(p.lexer.source, offset, text, expected, err)
+}
+
+// checkIndex returns positioned err if index isn't in [0, length)
+func (p *indexedParser) checkIndex(place token, index int, length int, name string, err error) error {
+	if index >= 0 && index < length {
+		return nil
+	}
+	return p.errorAt(place.offset, place.text, []string{fmt.Sprintf("%s less than %d", name, length)}, err)
 }
 
 type parseStorage struct {
 	knownPath      []DataPath
 	checkArguments []any
+	// if not nil, pattern ids are validated at parse time
+	patterns *PatternRegistry
+}
+
+// path returns known path by index, place is token of index
+func (p *indexedParser) path(place token, index int) (DataPath, error) {
+	if indexError := p.checkIndex(place, index, len(p.storage.knownPath), "path index", indexOutOfRangeError); indexError != nil {
+		return DataPath{}, indexError
+	}
+	return p.storage.knownPath[index], nil
+}
+
+// argument returns check argument by index, place is token of index
+func (p *indexedParser) argument(place token, index int) (any, error) {
+	if indexError := p.checkIndex(place, index, len(p.storage.checkArguments), "argument index", indexOutOfRangeError); indexError != nil {
+		return nil, indexError
+	}
+	return p.storage.checkArguments[index], nil
 }
 
 func parseExpressionTree(source string, storage *parseStorage) (PredicateWithError, error) {
-	reader := newSourceReader(source)
-	expression, expressionError := parseExpression(reader, storage)
-	if expressionError != nil {
-		return nil, expressionError
+	node, nodeError := parseExpressionTreeNode(source, storage)
+	if nodeError != nil {
+		return nil, nodeError
+	}
+	return CompileWithOptions(node, CompileOptions{Patterns: storage.patterns})
+}
+
+func parseExpressionTreeNode(source string, storage *parseStorage) (Node, error) {
+	parser, parserError := newIndexedParser(source, storage)
+	if parserError != nil {
+		return nil, parserError
+	}
+	node, nodeError := parser.parseExpression()
+	if nodeError != nil {
+		return nil, nodeError
 	}
-	if !reader.isEmpty() {
-		return nil, parseError
+	if parser.current.kind != tokenEOF {
+		return nil, parser.errorAt(parser.current.offset, source[parser.current.offset:], []string{tokenEOF.String()}, parseError)
 	}
-	return expression, nil
+	return node, nil
 }
 
-func parseExpression(reader *sourceReader, storage *parseStorage) (PredicateWithError, error) {
-	expressionHead, readError := reader.readTo("(")
-	if readError != nil {
-		return nil, readError
+func (p *indexedParser) parseExpression() (Node, error) {
+	head, headError := p.expect(tokenWord, expressionHeads...)
+	if headError != nil {
+		return nil, headError
+	}
+	if _, openError := p.expect(tokenLeftParen); openError != nil {
+		return nil, openError
 	}
-	switch strings.TrimSuffix(expressionHead, "(") {
+	switch head.text {
 	case "AND":
-		arguments, argumentsError := parseLogicalExpressionArgs(reader, storage)
+		arguments, argumentsError := p.parseLogicalExpressionArgs()
 		if argumentsError != nil {
 			return nil, argumentsError
 		}
-		return createLogicalAnd(arguments...), nil
+		return CreateAndNode(arguments...), nil
 	case "OR":
-		arguments, argumentsError := parseLogicalExpressionArgs(reader, storage)
+		arguments, argumentsError := p.parseLogicalExpressionArgs()
 		if argumentsError != nil {
 			return nil, argumentsError
 		}
-		return createLogicalOr(arguments...), nil
+		return CreateOrNode(arguments...), nil
 	case "NOT":
-		innerExpression, innerExpressionErr := parseNotArg(reader, storage)
+		innerExpression, innerExpressionErr := p.parseExpression()
 		if innerExpressionErr != nil {
 			return nil, innerExpressionErr
 		}
-		return createLogicalNot(innerExpression), nil
+		if _, closeError := p.expect(tokenRightParen); closeError != nil {
+			return nil, closeError
+		}
+		return CreateNotNode(innerExpression), nil
 	case "CHECK":
-		return parseCheck(reader, storage)
+		return p.parseCheck()
 	case "EXISTS":
-		return parseExists(reader, storage)
+		return p.parseExists()
 	case "MATCH":
-		return parseMatch(reader, storage)
+		return p.parseMatch()
 	default:
-		return nil, unknownExpressionError
+		return nil, p.errorAt(head.offset, head.text, expressionHeads, unknownExpressionError)
 	}
 }
 
-func parseLogicalExpressionArgs(reader *sourceReader, storage *parseStorage) ([]PredicateWithError, error) {
-	arguments := make([]PredicateWithError, 0)
+func (p *indexedParser) parseLogicalExpressionArgs() ([]Node, error) {
+	arguments := make([]Node, 0)
 	for {
-		argument, argumentError := parseExpression(reader, storage)
+		argument, argumentError := p.parseExpression()
 		if argumentError != nil {
 			return nil, argumentError
 		}
 		arguments = append(arguments, argument)
-		currentRune, readError := reader.readCurrent()
-		if readError != nil {
-			return nil, readError
-		}
-		switch currentRune {
-		case ",":
-		case ")":
+		separator := p.current
+		switch separator.kind {
+		case tokenComma:
+		case tokenRightParen:
 			if len(arguments) <= 1 {
-				return nil, badArgsError
+				return nil, p.errorAt(separator.offset, separator.text, []string{tokenComma.String()}, badArgsError)
 			}
-			return arguments, nil
+			return arguments, p.advance()
 		default:
-			return nil, parseError
+			return nil, p.errorAt(separator.offset, separator.text, []string{tokenComma.String(), tokenRightParen.String()}, parseError)
+		}
+		if advanceError := p.advance(); advanceError != nil {
+			return nil, advanceError
 		}
 	}
 }
 
-func parseNotArg(reader *sourceReader, storage *parseStorage) (PredicateWithError, error) {
-	innerExpression, innerExpressionErr := parseExpression(reader, storage)
-	if innerExpressionErr != nil {
-		return nil, innerExpressionErr
+func (p *indexedParser) parseExists() (Node, error) {
+	arguments, argumentsError := p.parseArguments(1)
+	if argumentsError != nil {
+		return nil, argumentsError
 	}
-	currentRune, readError := reader.readCurrent()
-	if readError != nil {
-		return nil, readError
+	path, pathError := p.path(arguments.tokens[0], arguments.values[0])
+	if pathError != nil {
+		return nil, pathError
 	}
-	if currentRune != ")" {
-		return nil, parseError
+	if _, expressionError := createExists(path); expressionError != nil {
+		return nil, p.errorAt(arguments.offset, arguments.text, nil, expressionError)
 	}
-	return innerExpression, nil
+	return CreateExistsNode(path), nil
 }
 
-func parseExists(reader *sourceReader, storage *parseStorage) (PredicateWithError, error) {
-	value, readError := reader.readTo(")")
-	if readError != nil {
-		return nil, readError
-	}
-	arguments, argumentsError := parseArguments(strings.TrimSuffix(value, ")"), 1)
+func (p *indexedParser) parseMatch() (Node, error) {
+	arguments, argumentsError := p.parseArguments(2)
 	if argumentsError != nil {
 		return nil, argumentsError
 	}
-	path := storage.knownPath[arguments[0]]
-	return createExists(path)
-}
-
-func parseMatch(reader *sourceReader, storage *parseStorage) (PredicateWithError, error) {
-	value, readError := reader.readTo(")")
-	if readError != nil {
-		return nil, readError
+	path, pathError := p.path(arguments.tokens[0], arguments.values[0])
+	if pathError != nil {
+		return nil, pathError
 	}
-	arguments, argumentsError := parseArguments(strings.TrimSuffix(value, ")"), 2)
-	if argumentsError != nil {
-		return nil, argumentsError
+	if arguments.values[1] < 0 {
+		return nil, p.errorAt(arguments.tokens[1].offset, arguments.tokens[1].text, []string{"pattern id"}, badArgsError)
+	}
+	patternId := uint(arguments.values[1])
+	if patternError := p.storage.patterns.validatePattern(patternId); patternError != nil {
+		return nil, p.errorAt(arguments.offset, arguments.text, nil, patternError)
+	}
+	if _, expressionError := createMatch(path, patternId); expressionError != nil {
+		return nil, p.errorAt(arguments.offset, arguments.text, nil, expressionError)
 	}
-	path := storage.knownPath[arguments[0]]
-	patternId := arguments[1]
-	return createMatch(path, uint(patternId))
+	return CreateMatchNode(path, patternId), nil
 }
 
-func parseCheck(reader *sourceReader, storage *parseStorage) (PredicateWithError, error) {
-	value, readError := reader.readTo(")")
-	if readError != nil {
-		return nil, readError
-	}
-	arguments, argumentsError := parseArguments(strings.TrimSuffix(value, ")"), 3)
+func (p *indexedParser) parseCheck() (Node, error) {
+	arguments, argumentsError := p.parseArguments(3)
 	if argumentsError != nil {
 		return nil, argumentsError
 	}
-	path := storage.knownPath[arguments[0]]
-	operation := arguments[1]
-	checkArg := storage.checkArguments[arguments[2]]
+	path, pathError := p.path(arguments.tokens[0], arguments.values[0])
+	if pathError != nil {
+		return nil, pathError
+	}
+	operation := arguments.values[1]
+	if operationError := p.checkIndex(arguments.tokens[1], operation, OperationBetween+1, "operation code", unsupportedOperationError); operationError != nil {
+		return nil, operationError
+	}
+	checkArg, checkArgError := p.argument(arguments.tokens[2], arguments.values[2])
+	if checkArgError != nil {
+		return nil, checkArgError
+	}
 	predicate, predicateError := parsePredicate(operation, checkArg)
 	if predicateError != nil {
-		return nil, predicateError
+		return nil, p.errorAt(arguments.offset, arguments.text, nil, predicateError)
+	}
+	if _, expressionError := createCheck(path, predicate); expressionError != nil {
+		return nil, p.errorAt(arguments.offset, arguments.text, nil, expressionError)
 	}
-	return createCheck(path, predicate)
+	return CreateCheckNode(path, operation, checkArg), nil
 }
 
-func parseArguments(source string, expectedParts int) ([]int, error) {
-	arguments := strings.Split(source, ",")
-	if len(arguments) != expectedParts {
-		return nil, badArgsError
+// parseArguments reads comma separated integers and closing parenthesis,
+// count of arguments is checked before arguments (so empty argument of single argument expression is parse error)
+func (p *indexedParser) parseArguments(expectedParts int) (indexedArguments, error) {
+	start := p.current.offset
+	// tokens of each argument and token after argument (comma or closing parenthesis)
+	var parts [][]token
+	var ends []token
+	var part []token
+	for {
+		current := p.current
+		switch current.kind {
+		case tokenEOF:
+			return indexedArguments{}, p.errorAt(current.offset, current.text, []string{tokenRightParen.String()}, parseError)
+		case tokenWord:
+			part = append(part, current)
+		case tokenComma, tokenRightParen:
+			parts = append(parts, part)
+			ends = append(ends, current)
+			part = nil
+		default:
+			return indexedArguments{}, p.errorAt(current.offset, current.text, []string{"integer"}, parseError)
+		}
+		if advanceError := p.advance(); advanceError != nil {
+			return indexedArguments{}, advanceError
+		}
+		if current.kind == tokenRightParen {
+			break
+		}
+	}
+	closing := ends[len(ends)-1]
+	arguments := indexedArguments{text: p.lexer.source[start:closing.offset], offset: start}
+	if len(parts) != expectedParts {
+		text := arguments.text
+		if text == "" {
+			text = closing.text
+		}
+		return indexedArguments{}, p.errorAt(start, text, []string{fmt.Sprintf("%d arguments", expectedParts)}, badArgsError)
 	}
-	result := make([]int, len(arguments))
-	for index, argument := range arguments {
-		value, convertError := strconv.Atoi(argument)
+	for index, argumentTokens := range parts {
+		if len(argumentTokens) == 0 {
+			return indexedArguments{}, p.errorAt(ends[index].offset, ends[index].text, []string{"integer"}, parseError)
+		}
+		if len(argumentTokens) > 1 {
+			return indexedArguments{}, p.errorAt(argumentTokens[1].offset, argumentTokens[1].text, []string{ends[index].text}, parseError)
+		}
+		value, convertError := strconv.Atoi(argumentTokens[0].text)
 		if convertError != nil {
-			return nil, parseError
+			return indexedArguments{}, p.errorAt(argumentTokens[0].offset, argumentTokens[0].text, []string{"integer"}, parseError)
 		}
-		result[index] = value
+		arguments.values = append(arguments.values, value)
+		arguments.tokens = append(arguments.tokens, argumentTokens[0])
 	}
-	return result, nil
+	return arguments, nil
 }
 
 func parsePredicate(operation int, argument any) (Predicate, error) {
-	// TODO (std_string) : this is simple version for demo. in real version we must use type casting
 	switch operation {
 	case OperationEqual:
-		return func(value any) bool {
-			return value == argument
-		}, nil
+		return createEqualPredicate(argument, false)
 	case OperationNotEqual:
-		return func(value any) bool {
-			return value != argument
-		}, nil
+		return createEqualPredicate(argument, true)
+	case OperationLess:
+		return createOrderPredicate(argument, func(compareResult int) bool { return compareResult < 0 })
+	case OperationLessOrEqual:
+		return createOrderPredicate(argument, func(compareResult int) bool { return compareResult <= 0 })
+	case OperationGreater:
+		return createOrderPredicate(argument, func(compareResult int) bool { return compareResult > 0 })
+	case OperationGreaterOrEqual:
+		return createOrderPredicate(argument, func(compareResult int) bool { return compareResult >= 0 })
+	case OperationIn:
+		return createInPredicate(argument, false)
+	case OperationNotIn:
+		return createInPredicate(argument, true)
+	case OperationContains:
+		return createStringPredicate(argument, strings.Contains)
+	case OperationStartsWith:
+		return createStringPredicate(argument, strings.HasPrefix)
+	case OperationEndsWith:
+		return createStringPredicate(argument, strings.HasSuffix)
+	case OperationBetween:
+		return createBetweenPredicate(argument)
 	default:
 		return nil, unsupportedOperationError
 	}
 }
 
 func createLogicalAnd(predicates ...PredicateWithError) PredicateWithError {
-	return func(data *HttpData, manager IExecutionManager) (bool, error) {
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
 		for _, predicate := range predicates {
-			result, err := predicate(data, manager)
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			result, err := predicate(ctx, data, manager)
 			if err != nil {
 				return false, err
 			}
@@ -248,9 +386,12 @@ func createLogicalAnd(predicates ...PredicateWithError) PredicateWithError {
 }
 
 func createLogicalOr(predicates ...PredicateWithError) PredicateWithError {
-	return func(data *HttpData, manager IExecutionManager) (bool, error) {
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
 		for _, predicate := range predicates {
-			result, err := predicate(data, manager)
+			if contextError := evaluationContextError(ctx); contextError != nil {
+				return false, contextError
+			}
+			result, err := predicate(ctx, data, manager)
 			if err != nil {
 				return false, err
 			}
@@ -263,8 +404,8 @@ func createLogicalOr(predicates ...PredicateWithError) PredicateWithError {
 }
 
 func createLogicalNot(predicate PredicateWithError) PredicateWithError {
-	return func(data *HttpData, manager IExecutionManager) (bool, error) {
-		result, err := predicate(data, manager)
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		result, err := predicate(ctx, data, manager)
 		if err != nil {
 			return false, err
 		}
@@ -275,132 +416,132 @@ func createLogicalNot(predicate PredicateWithError) PredicateWithError {
 func createMatch(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch path.MainPath {
 	case HttpDataKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchHttpData(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchHttpData(ctx, patternId, data)
 		}, nil
 	case HttpDataHostKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchHttpDataHost(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchHttpDataHost(ctx, patternId, data)
 		}, nil
 	case HttpDataProtocolKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchHttpDataProtocol(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchHttpDataProtocol(ctx, patternId, data)
 		}, nil
 	case HttpDataPortKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchHttpDataPort(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchHttpDataPort(ctx, patternId, data)
 		}, nil
 	case HttpDataHttpVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchHttpDataHttpVersion(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchHttpDataHttpVersion(ctx, patternId, data)
 		}, nil
 	case HttpDataTimestampKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchHttpDataTimestamp(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchHttpDataTimestamp(ctx, patternId, data)
 		}, nil
 	case OptionsKey:
 		return generateMatchOption(path, patternId)
 	case ClientKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchClient(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchClient(ctx, patternId, data)
 		}, nil
 	case ClientIdKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchClientId(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchClientId(ctx, patternId, data)
 		}, nil
 	case ClientIpKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchClientIp(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchClientIp(ctx, patternId, data)
 		}, nil
 	case GeoIpKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchGeoIp(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchGeoIp(ctx, patternId, data)
 		}, nil
 	case GeoIpCountryKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpCountry(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpCountry(ctx, patternId, data)
 		}, nil
 	case GeoIpCountryCodeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpCountryCode(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpCountryCode(ctx, patternId, data)
 		}, nil
 	case GeoIpCityKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpCity(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpCity(ctx, patternId, data)
 		}, nil
 	case GeoIpLatKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpLat(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpLat(ctx, patternId, data)
 		}, nil
 	case GeoIpLonKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpLon(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpLon(ctx, patternId, data)
 		}, nil
 	case GeoIpAccuracyRadiusKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchGeoIpAccuracyRadius(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchGeoIpAccuracyRadius(ctx, patternId, data)
 		}, nil
 	case OsKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchOs(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchOs(ctx, patternId, data)
 		}, nil
 	case OsNameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchOsName(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchOsName(ctx, patternId, data)
 		}, nil
 	case OsVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchOsVersion(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchOsVersion(ctx, patternId, data)
 		}, nil
 	case BrowserKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchBrowser(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchBrowser(ctx, patternId, data)
 		}, nil
 	case BrowserNameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchBrowserName(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchBrowserName(ctx, patternId, data)
 		}, nil
 	case BrowserVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchBrowserVersion(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchBrowserVersion(ctx, patternId, data)
 		}, nil
 	case BasicAuthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchBasicAuth(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchBasicAuth(ctx, patternId, data)
 		}, nil
 	case BasicAuthUsernameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchBasicAuthUsername(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchBasicAuthUsername(ctx, patternId, data)
 		}, nil
 	case BasicAuthPasswordKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchBasicAuthPassword(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchBasicAuthPassword(ctx, patternId, data)
 		}, nil
 	case RequestKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequest(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequest(ctx, patternId, data)
 		}, nil
 	case RequestIdKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestId(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestId(ctx, patternId, data)
 		}, nil
 	case RequestPathKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestPath(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestPath(ctx, patternId, data)
 		}, nil
 	case RequestPathsKey:
 		return generateMatchRequestPaths(path, patternId)
 	case RequestQueryKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestQuery(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestQuery(ctx, patternId, data)
 		}, nil
 	case RequestMethodKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestMethod(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestMethod(ctx, patternId, data)
 		}, nil
 	case RequestBodyKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestBody(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestBody(ctx, patternId, path.ContentPath, data)
 		}, nil
 	case RequestGetKey:
 		return generateMatchRequestGet(path, patternId)
@@ -409,36 +550,36 @@ func createMatch(path DataPath, patternId uint) (PredicateWithError, error) {
 	case RequestHeadersKey:
 		return generateMatchRequestHeaders(path, patternId)
 	case RequestTimeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestTime(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestTime(ctx, patternId, data)
 		}, nil
 	case RequestCookiesKey:
 		return generateMatchRequestCookies(path, patternId)
 	case RequestLengthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestLength(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestLength(ctx, patternId, data)
 		}, nil
 	case ResponseKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchResponse(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchResponse(ctx, patternId, data)
 		}, nil
 	case ResponseBodyKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchResponseBody(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchResponseBody(ctx, patternId, path.ContentPath, data)
 		}, nil
 	case ResponseCodeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchResponseCode(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchResponseCode(ctx, patternId, data)
 		}, nil
 	case ResponseSourceKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchResponseSource(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchResponseSource(ctx, patternId, data)
 		}, nil
 	case ResponseHeadersKey:
 		return generateMatchResponseHeaders(path, patternId)
 	case ResponseLengthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchResponseLength(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchResponseLength(ctx, patternId, data)
 		}, nil
 	default:
 		return nil, unknownMainPathError
@@ -451,43 +592,43 @@ func createExists(path DataPath) (PredicateWithError, error) {
 		if !path.ContentPath.IsSimple() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckOptionExistence(path.ContentPath.Path, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckOptionExistence(ctx, path.ContentPath.Path, data)
 		}, nil
 	case RequestGetKey:
 		if path.ContentPath.IsEmpty() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestGetValueExistence(path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestGetValueExistence(ctx, path.ContentPath, data)
 		}, nil
 	case RequestPostKey:
 		if path.ContentPath.IsEmpty() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestPostValueExistence(path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestPostValueExistence(ctx, path.ContentPath, data)
 		}, nil
 	case RequestHeadersKey:
 		if path.ContentPath.IsEmpty() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestHeaderValueExistence(path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestHeaderValueExistence(ctx, path.ContentPath, data)
 		}, nil
 	case RequestCookiesKey:
 		if path.ContentPath.IsEmpty() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestCookieValueExistence(path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestCookieValueExistence(ctx, path.ContentPath, data)
 		}, nil
 	case ResponseHeadersKey:
 		if path.ContentPath.IsEmpty() {
 			return nil, badContentPathError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckResponseHeaderValueExistence(path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckResponseHeaderValueExistence(ctx, path.ContentPath, data)
 		}, nil
 	default:
 		return nil, unknownMainPathError
@@ -497,132 +638,132 @@ func createExists(path DataPath) (PredicateWithError, error) {
 func createCheck(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch path.MainPath {
 	case HttpDataKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckHttpData(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckHttpData(ctx, predicate, data)
 		}, nil
 	case HttpDataHostKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckHttpDataHost(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckHttpDataHost(ctx, predicate, data)
 		}, nil
 	case HttpDataProtocolKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckHttpDataProtocol(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckHttpDataProtocol(ctx, predicate, data)
 		}, nil
 	case HttpDataPortKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckHttpDataPort(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckHttpDataPort(ctx, predicate, data)
 		}, nil
 	case HttpDataHttpVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckHttpDataHttpVersion(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckHttpDataHttpVersion(ctx, predicate, data)
 		}, nil
 	case HttpDataTimestampKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckHttpDataTimestamp(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckHttpDataTimestamp(ctx, predicate, data)
 		}, nil
 	case OptionsKey:
 		return generateCheckOption(path, predicate)
 	case ClientKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckClient(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckClient(ctx, predicate, data)
 		}, nil
 	case ClientIdKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckClientId(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckClientId(ctx, predicate, data)
 		}, nil
 	case ClientIpKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckClientIp(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckClientIp(ctx, predicate, data)
 		}, nil
 	case GeoIpKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckGeoIp(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckGeoIp(ctx, predicate, data)
 		}, nil
 	case GeoIpCountryKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpCountry(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpCountry(ctx, predicate, data)
 		}, nil
 	case GeoIpCountryCodeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpCountryCode(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpCountryCode(ctx, predicate, data)
 		}, nil
 	case GeoIpCityKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpCity(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpCity(ctx, predicate, data)
 		}, nil
 	case GeoIpLatKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpLat(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpLat(ctx, predicate, data)
 		}, nil
 	case GeoIpLonKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpLon(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpLon(ctx, predicate, data)
 		}, nil
 	case GeoIpAccuracyRadiusKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckGeoIpAccuracyRadius(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckGeoIpAccuracyRadius(ctx, predicate, data)
 		}, nil
 	case OsKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckOs(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckOs(ctx, predicate, data)
 		}, nil
 	case OsNameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckOsName(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckOsName(ctx, predicate, data)
 		}, nil
 	case OsVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckOsVersion(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckOsVersion(ctx, predicate, data)
 		}, nil
 	case BrowserKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckBrowser(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckBrowser(ctx, predicate, data)
 		}, nil
 	case BrowserNameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckBrowserName(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckBrowserName(ctx, predicate, data)
 		}, nil
 	case BrowserVersionKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckBrowserVersion(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckBrowserVersion(ctx, predicate, data)
 		}, nil
 	case BasicAuthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckBasicAuth(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckBasicAuth(ctx, predicate, data)
 		}, nil
 	case BasicAuthUsernameKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckBasicAuthUsername(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckBasicAuthUsername(ctx, predicate, data)
 		}, nil
 	case BasicAuthPasswordKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckBasicAuthPassword(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckBasicAuthPassword(ctx, predicate, data)
 		}, nil
 	case RequestKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequest(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequest(ctx, predicate, data)
 		}, nil
 	case RequestIdKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestId(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestId(ctx, predicate, data)
 		}, nil
 	case RequestPathKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestPath(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestPath(ctx, predicate, data)
 		}, nil
 	case RequestPathsKey:
 		return generateCheckRequestPaths(path, predicate)
 	case RequestQueryKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestQuery(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestQuery(ctx, predicate, data)
 		}, nil
 	case RequestMethodKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestMethod(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestMethod(ctx, predicate, data)
 		}, nil
 	case RequestBodyKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestBody(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestBody(ctx, predicate, path.ContentPath, data)
 		}, nil
 	case RequestGetKey:
 		return generateCheckRequestGet(path, predicate)
@@ -631,36 +772,36 @@ func createCheck(path DataPath, predicate Predicate) (PredicateWithError, error)
 	case RequestHeadersKey:
 		return generateCheckRequestHeaders(path, predicate)
 	case RequestTimeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestTime(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestTime(ctx, predicate, data)
 		}, nil
 	case RequestCookiesKey:
 		return generateCheckRequestCookies(path, predicate)
 	case RequestLengthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestLength(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestLength(ctx, predicate, data)
 		}, nil
 	case ResponseKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckResponse(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckResponse(ctx, predicate, data)
 		}, nil
 	case ResponseBodyKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckResponseBody(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckResponseBody(ctx, predicate, path.ContentPath, data)
 		}, nil
 	case ResponseCodeKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckResponseCode(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckResponseCode(ctx, predicate, data)
 		}, nil
 	case ResponseSourceKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckResponseSource(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckResponseSource(ctx, predicate, data)
 		}, nil
 	case ResponseHeadersKey:
 		return generateCheckResponseHeaders(path, predicate)
 	case ResponseLengthKey:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckResponseLength(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckResponseLength(ctx, predicate, data)
 		}, nil
 	default:
 		return nil, unknownMainPathError
@@ -670,12 +811,12 @@ func createCheck(path DataPath, predicate Predicate) (PredicateWithError, error)
 func generateMatchOption(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchOptions(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchOptions(ctx, patternId, data)
 		}, nil
 	case path.ContentPath.IsSimple():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchOption(patternId, path.ContentPath.Path, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchOption(ctx, patternId, path.ContentPath.Path, data)
 		}, nil
 	default:
 		return nil, badContentPathError
@@ -685,16 +826,16 @@ func generateMatchOption(path DataPath, patternId uint) (PredicateWithError, err
 func generateMatchRequestPaths(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestPaths(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestPaths(ctx, patternId, data)
 		}, nil
 	default:
 		index, convertError := strconv.Atoi(path.ContentPath.Parts[0])
 		if convertError != nil {
 			return nil, badRequestPathIndexError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.MatchRequestPathsElement(patternId, index, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.MatchRequestPathsElement(ctx, patternId, index, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -702,12 +843,12 @@ func generateMatchRequestPaths(path DataPath, patternId uint) (PredicateWithErro
 func generateMatchRequestGet(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestGet(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestGet(ctx, patternId, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestGetValue(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestGetValue(ctx, patternId, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -715,12 +856,12 @@ func generateMatchRequestGet(path DataPath, patternId uint) (PredicateWithError,
 func generateMatchRequestPost(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestPost(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestPost(ctx, patternId, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestPostValue(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestPostValue(ctx, patternId, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -728,12 +869,12 @@ func generateMatchRequestPost(path DataPath, patternId uint) (PredicateWithError
 func generateMatchRequestHeaders(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestHeaders(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestHeaders(ctx, patternId, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestHeaderValue(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestHeaderValue(ctx, patternId, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -741,12 +882,12 @@ func generateMatchRequestHeaders(path DataPath, patternId uint) (PredicateWithEr
 func generateMatchRequestCookies(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestCookies(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestCookies(ctx, patternId, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchRequestCookieValue(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchRequestCookieValue(ctx, patternId, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -754,12 +895,12 @@ func generateMatchRequestCookies(path DataPath, patternId uint) (PredicateWithEr
 func generateMatchResponseHeaders(path DataPath, patternId uint) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchResponseHeaders(patternId, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchResponseHeaders(ctx, patternId, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveMatchResponseHeaderValue(patternId, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveMatchResponseHeaderValue(ctx, patternId, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -767,12 +908,12 @@ func generateMatchResponseHeaders(path DataPath, patternId uint) (PredicateWithE
 func generateCheckOption(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckOptions(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckOptions(ctx, predicate, data)
 		}, nil
 	case path.ContentPath.IsSimple():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckOption(predicate, path.ContentPath.Path, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckOption(ctx, predicate, path.ContentPath.Path, data)
 		}, nil
 	default:
 		return nil, badContentPathError
@@ -782,16 +923,16 @@ func generateCheckOption(path DataPath, predicate Predicate) (PredicateWithError
 func generateCheckRequestPaths(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestPaths(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestPaths(ctx, predicate, data)
 		}, nil
 	default:
 		index, convertError := strconv.Atoi(path.ContentPath.Parts[0])
 		if convertError != nil {
 			return nil, badRequestPathIndexError
 		}
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.CheckRequestPathsElement(predicate, index, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.CheckRequestPathsElement(ctx, predicate, index, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -799,12 +940,12 @@ func generateCheckRequestPaths(path DataPath, predicate Predicate) (PredicateWit
 func generateCheckRequestGet(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestGet(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestGet(ctx, predicate, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestGetValue(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestGetValue(ctx, predicate, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -812,12 +953,12 @@ func generateCheckRequestGet(path DataPath, predicate Predicate) (PredicateWithE
 func generateCheckRequestPost(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestPost(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestPost(ctx, predicate, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestPostValue(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestPostValue(ctx, predicate, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -825,12 +966,12 @@ func generateCheckRequestPost(path DataPath, predicate Predicate) (PredicateWith
 func generateCheckRequestHeaders(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestHeaders(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestHeaders(ctx, predicate, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestHeaderValue(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestHeaderValue(ctx, predicate, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -838,12 +979,12 @@ func generateCheckRequestHeaders(path DataPath, predicate Predicate) (PredicateW
 func generateCheckRequestCookies(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestCookies(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestCookies(ctx, predicate, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckRequestCookieValue(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckRequestCookieValue(ctx, predicate, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -851,12 +992,12 @@ func generateCheckRequestCookies(path DataPath, predicate Predicate) (PredicateW
 func generateCheckResponseHeaders(path DataPath, predicate Predicate) (PredicateWithError, error) {
 	switch {
 	case path.ContentPath.IsEmpty():
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckResponseHeaders(predicate, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckResponseHeaders(ctx, predicate, data)
 		}, nil
 	default:
-		return func(data *HttpData, manager IExecutionManager) (bool, error) {
-			return manager.RecursiveCheckResponseHeaderValue(predicate, path.ContentPath, data)
+		return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+			return manager.RecursiveCheckResponseHeaderValue(ctx, predicate, path.ContentPath, data)
 		}, nil
 	}
 }
@@ -0,0 +1,65 @@
+package expressiontree
+
+import (
+	"testing"
+)
+
+func createFuzzStorage() *parseStorage {
+	return &parseStorage{
+		knownPath: []DataPath{
+			CreateDataPathWithMainOnly(HttpDataKey),
+			CreateDataPathWithSimpleContent(OptionsKey, "IDDQD"),
+			CreateDataPathWithSimpleContent(RequestHeadersKey, "IDKFA"),
+			CreateDataPathWithMainOnly(HttpDataPortKey),
+		},
+		checkArguments: []any{"", "IDCLIP", 80, []any{80, 443}, nil},
+		patterns:       CreatePatternRegistry(),
+	}
+}
+
+// FuzzParseExpressionTree checks that indexed parser returns node or error and never panics
+func FuzzParseExpressionTree(f *testing.F) {
+	for _, seed := range []string{
+		"EXISTS(1)", "MATCH(1,666)", "CHECK(1,0,1)", "CHECK(3,6,3)", "NOT(EXISTS(1))",
+		"AND(EXISTS(1),CHECK(3,11,3))", "OR(EXISTS(1),MATCH(0,1),NOT(CHECK(2,8,1)))",
+		"EXISTS(99)", "CHECK(-1,0,0)", "CHECK(0,12,0)", "EXISTS()", "AND()", "AND(EXISTS(1))", "NOT()",
+	} {
+		f.Add(seed)
+	}
+	storage := createFuzzStorage()
+	f.Fuzz(func(t *testing.T, source string) {
+		node, nodeError := parseExpressionTreeNode(source, storage)
+		if nodeError != nil {
+			if _, isParseError := nodeError.(*ParseError); !isParseError {
+				t.Fatalf("%q: error %v isn't ParseError", source, nodeError)
+			}
+			return
+		}
+		if _, compileError := CompileWithOptions(node, CompileOptions{Patterns: storage.patterns}); compileError != nil {
+			t.Fatalf("%q: parsed node %v isn't compiled: %v", source, node, compileError)
+		}
+	})
+}
+
+// FuzzParseRule checks that rule parser returns node or error and never panics
+func FuzzParseRule(f *testing.F) {
+	for _, seed := range []string{
+		`CHECK(http.request.method == "GET")`, "EXISTS(http.options.IDDQD)", "MATCH(http.request.body, 1)",
+		`AND(CHECK(http.port IN (80, 443)), NOT(CHECK(http.request.time BETWEEN "1s" AND "2s")))`,
+		`OR(TRUE(), FALSE(), CHECK(http.request.headers."X-Token" STARTSWITH "a"))`, "CHECK(http.port == -1.5e3)",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		node, nodeError := ParseRuleNode(source)
+		if nodeError != nil {
+			if _, isParseError := nodeError.(*ParseError); !isParseError {
+				t.Fatalf("%q: error %v isn't ParseError", source, nodeError)
+			}
+			return
+		}
+		if _, compileError := Compile(node); compileError != nil {
+			t.Fatalf("%q: parsed node %v isn't compiled: %v", source, node, compileError)
+		}
+	})
+}
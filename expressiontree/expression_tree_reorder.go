@@ -0,0 +1,322 @@
+package expressiontree
+
+import (
+	"math"
+	"sort"
+)
+
+// Cost model: cost of leaf is relative cost of getting values for its main path (see defaultDataKeyCosts),
+// MATCH costs twice as much as CHECK/EXISTS. Selectivity of leaf is probability that leaf is true,
+// it is learned by SelectivityStats or is 0.5 by default. Operands are assumed independent:
+// AND(a,b): cost = cost(a) + p(a)*cost(b), p = p(a)*p(b)
+// OR(a,b): cost = cost(a) + (1-p(a))*cost(b), p = 1-(1-p(a))*(1-p(b))
+// Operands of AND are sorted by cost/(1-p), operands of OR - by cost/p (optimal order for independent operands),
+// equal operands keep source order.
+
+// ReorderErrorPolicy defines how operands which can return error are reordered
+type ReorderErrorPolicy int
+
+const (
+	// PreserveErrors - operands which can fail (see ICostEstimator.CanFail) aren't moved and other operands
+	// aren't moved over them, so results and errors are the same as for original expression.
+	// Default estimates assume default execution manager and valid pattern ids (see CompileOptions.Patterns).
+	PreserveErrors ReorderErrorPolicy = iota
+	// IgnoreErrors - all operands are reordered, results are the same if no operand fails,
+	// otherwise reordered expression may return result instead of error and vice versa.
+	IgnoreErrors
+)
+
+const defaultSelectivity = 0.5
+const defaultDataKeyCost = 1.0
+const matchCostFactor = 2.0
+
+// ICostEstimator can be implemented by execution manager to override default estimates of cost model
+type ICostEstimator interface {
+	// EstimateCost returns relative cost of getting values for main path, ok == false means default estimate
+	EstimateCost(key TDataKey) (cost float64, ok bool)
+	// CanFail returns true if leaf (CHECK, EXISTS or MATCH node) can return error, ok == false means default estimate
+	CanFail(leaf Node) (canFail bool, ok bool)
+}
+
+type CostModel struct {
+	// if nil, default estimates are used
+	Estimator ICostEstimator
+	// if nil, selectivity of all leaves is 0.5
+	Stats       *SelectivityStats
+	ErrorPolicy ReorderErrorPolicy
+}
+
+// CreateCostModel uses manager as estimator if it implements ICostEstimator
+func CreateCostModel(manager IExecutionManager, stats *SelectivityStats, errorPolicy ReorderErrorPolicy) CostModel {
+	estimator, _ := manager.(ICostEstimator)
+	return CostModel{Estimator: estimator, Stats: stats, ErrorPolicy: errorPolicy}
+}
+
+var defaultDataKeyCosts = map[TDataKey]float64{
+	HttpDataKey:        100,
+	OptionsKey:         2,
+	ClientKey:          10,
+	GeoIpKey:           5,
+	OsKey:              2,
+	BrowserKey:         2,
+	BasicAuthKey:       2,
+	RequestKey:         50,
+	RequestPathsKey:    2,
+	RequestBodyKey:     20,
+	RequestGetKey:      3,
+	RequestPostKey:     3,
+	RequestHeadersKey:  3,
+	RequestCookiesKey:  3,
+	ResponseKey:        40,
+	ResponseBodyKey:    20,
+	ResponseHeadersKey: 3,
+}
+
+// keys with string values, CHECK with string arguments can't fail for them
+var stringDataKeys = map[TDataKey]bool{
+	HttpDataHostKey:        true,
+	HttpDataProtocolKey:    true,
+	HttpDataHttpVersionKey: true,
+	ClientIdKey:            true,
+	ClientIpKey:            true,
+	OsKey:                  true,
+	OsNameKey:              true,
+	OsVersionKey:           true,
+	BrowserKey:             true,
+	BrowserNameKey:         true,
+	BrowserVersionKey:      true,
+	BasicAuthKey:           true,
+	BasicAuthUsernameKey:   true,
+	BasicAuthPasswordKey:   true,
+	RequestIdKey:           true,
+	RequestPathKey:         true,
+	RequestPathsKey:        true,
+	RequestQueryKey:        true,
+	RequestMethodKey:       true,
+	ResponseSourceKey:      true,
+}
+
+// keys of multi maps, values are strings if content path has only name
+var multiMapDataKeys = map[TDataKey]bool{
+	RequestGetKey:      true,
+	RequestPostKey:     true,
+	RequestHeadersKey:  true,
+	RequestCookiesKey:  true,
+	ResponseHeadersKey: true,
+}
+
+// keys with integer, time or duration values, CHECK with number or string arguments can't fail for them
+var numberDataKeys = map[TDataKey]bool{
+	HttpDataPortKey:      true,
+	HttpDataTimestampKey: true,
+	RequestTimeKey:       true,
+	RequestLengthKey:     true,
+	ResponseCodeKey:      true,
+	ResponseLengthKey:    true,
+}
+
+// keys which MATCH can fail for: geoip data may be absent, options may contain values which aren't strings
+var failingMatchDataKeys = map[TDataKey]bool{
+	OptionsKey:             true,
+	GeoIpCountryKey:        true,
+	GeoIpCountryCodeKey:    true,
+	GeoIpCityKey:           true,
+	GeoIpLatKey:            true,
+	GeoIpLonKey:            true,
+	GeoIpAccuracyRadiusKey: true,
+}
+
+// keys of sections, MATCH of section visits all its leaves (including leaves of JSON content),
+// it is assumed that it can fail
+var sectionDataKeys = map[TDataKey]bool{
+	HttpDataKey:     true,
+	OptionsKey:      true,
+	ClientKey:       true,
+	GeoIpKey:        true,
+	OsKey:           true,
+	BrowserKey:      true,
+	BasicAuthKey:    true,
+	RequestKey:      true,
+	RequestBodyKey:  true,
+	ResponseKey:     true,
+	ResponseBodyKey: true,
+}
+
+type costEstimate struct {
+	cost        float64
+	selectivity float64
+	canFail     bool
+}
+
+// Reorder reorders operands of AND/OR by cost model, see ReorderErrorPolicy for error behavior
+func Reorder(node Node, model CostModel) Node {
+	reorderedNode, _ := model.estimate(node, true)
+	return reorderedNode
+}
+
+// EstimateCost returns expected cost of node evaluation (in source order) by cost model
+func (m CostModel) EstimateCost(node Node) float64 {
+	_, estimate := m.estimate(node, false)
+	return estimate.cost
+}
+
+// estimate returns estimate of node and node with reordered operands if reorder is true
+func (m CostModel) estimate(node Node, reorder bool) (Node, costEstimate) {
+	switch current := node.(type) {
+	case *AndNode:
+		operands, estimate := m.estimateOperands(current.Operands, true, reorder)
+		return CreateAndNode(operands...), estimate
+	case *OrNode:
+		operands, estimate := m.estimateOperands(current.Operands, false, reorder)
+		return CreateOrNode(operands...), estimate
+	case *NotNode:
+		operand, estimate := m.estimate(current.Operand, reorder)
+		estimate.selectivity = 1 - estimate.selectivity
+		return CreateNotNode(operand), estimate
+	case *ConstNode:
+		if current.Value {
+			return node, costEstimate{cost: 0, selectivity: 1, canFail: false}
+		}
+		return node, costEstimate{cost: 0, selectivity: 0, canFail: false}
+	case *CheckNode:
+		return node, costEstimate{cost: m.dataKeyCost(current.Path.MainPath), selectivity: m.selectivity(node), canFail: m.canFail(node)}
+	case *ExistsNode:
+		return node, costEstimate{cost: m.dataKeyCost(current.Path.MainPath), selectivity: m.selectivity(node), canFail: m.canFail(node)}
+	case *MatchNode:
+		cost := matchCostFactor * m.dataKeyCost(current.Path.MainPath)
+		return node, costEstimate{cost: cost, selectivity: m.selectivity(node), canFail: m.canFail(node)}
+	default:
+		return node, costEstimate{cost: defaultDataKeyCost, selectivity: defaultSelectivity, canFail: true}
+	}
+}
+
+func (m CostModel) estimateOperands(operands []Node, isAnd bool, reorder bool) ([]Node, costEstimate) {
+	type reorderedOperand struct {
+		node     Node
+		estimate costEstimate
+		rank     float64
+	}
+	items := make([]reorderedOperand, 0, len(operands))
+	for _, operand := range operands {
+		reorderedNode, estimate := m.estimate(operand, reorder)
+		// probability that operand decides result of AND/OR
+		decisive := estimate.selectivity
+		if isAnd {
+			decisive = 1 - estimate.selectivity
+		}
+		rank := math.Inf(1)
+		if decisive > 0 {
+			rank = estimate.cost / decisive
+		}
+		items = append(items, reorderedOperand{node: reorderedNode, estimate: estimate, rank: rank})
+	}
+	sortSegment := func(start int, end int) {
+		segment := items[start:end]
+		sort.SliceStable(segment, func(left int, right int) bool { return segment[left].rank < segment[right].rank })
+	}
+	switch {
+	case !reorder:
+	case m.ErrorPolicy == IgnoreErrors:
+		sortSegment(0, len(items))
+	default:
+		start := 0
+		for index, item := range items {
+			if item.estimate.canFail {
+				sortSegment(start, index)
+				start = index + 1
+			}
+		}
+		sortSegment(start, len(items))
+	}
+	result := make([]Node, 0, len(items))
+	total := costEstimate{cost: 0, selectivity: 1, canFail: false}
+	// probability that evaluation reaches current operand
+	reach := 1.0
+	for _, item := range items {
+		result = append(result, item.node)
+		total.cost += reach * item.estimate.cost
+		total.canFail = total.canFail || item.estimate.canFail
+		if isAnd {
+			reach *= item.estimate.selectivity
+		} else {
+			reach *= 1 - item.estimate.selectivity
+		}
+	}
+	total.selectivity = reach
+	if !isAnd {
+		total.selectivity = 1 - reach
+	}
+	return result, total
+}
+
+func (m CostModel) dataKeyCost(key TDataKey) float64 {
+	if m.Estimator != nil {
+		if cost, ok := m.Estimator.EstimateCost(key); ok {
+			return cost
+		}
+	}
+	if cost, exists := defaultDataKeyCosts[key]; exists {
+		return cost
+	}
+	return defaultDataKeyCost
+}
+
+func (m CostModel) selectivity(leaf Node) float64 {
+	if selectivity, ok := m.Stats.Selectivity(leaf); ok {
+		return selectivity
+	}
+	return defaultSelectivity
+}
+
+func (m CostModel) canFail(leaf Node) bool {
+	if m.Estimator != nil {
+		if canFail, ok := m.Estimator.CanFail(leaf); ok {
+			return canFail
+		}
+	}
+	return defaultCanFail(leaf)
+}
+
+func defaultCanFail(leaf Node) bool {
+	switch current := leaf.(type) {
+	case *ExistsNode:
+		return false
+	case *MatchNode:
+		key := current.Path.MainPath
+		if multiMapDataKeys[key] {
+			// values of multi map by name are strings, otherwise leaves of all values or of JSON content are matched
+			return len(current.Path.ContentPath.Parts) != 1
+		}
+		return failingMatchDataKeys[key] || sectionDataKeys[key]
+	case *CheckNode:
+		if current.Operation == OperationCustom {
+			// errors of custom operators are unknown
+			return true
+		}
+		key := current.Path.MainPath
+		arguments := []any{current.Argument}
+		if items, isList := current.Argument.([]any); isList {
+			arguments = items
+		}
+		stringValues := stringDataKeys[key] || (multiMapDataKeys[key] && len(current.Path.ContentPath.Parts) <= 1)
+		for _, argument := range arguments {
+			switch argument.(type) {
+			case nil:
+			case string:
+				if !stringValues && !numberDataKeys[key] {
+					return true
+				}
+			case bool:
+				return true
+			default:
+				// number argument
+				if !numberDataKeys[key] {
+					return true
+				}
+			}
+		}
+		return !stringValues && !numberDataKeys[key]
+	default:
+		return true
+	}
+}
@@ -0,0 +1,267 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// costEstimatorManager overrides costs of default execution manager
+type costEstimatorManager struct {
+	*ExecutionManager
+	costs         map[TDataKey]float64
+	failingLeaves map[string]bool
+	overridesFail bool
+}
+
+func (m *costEstimatorManager) EstimateCost(key TDataKey) (float64, bool) {
+	cost, exists := m.costs[key]
+	return cost, exists
+}
+
+func (m *costEstimatorManager) CanFail(leaf Node) (bool, bool) {
+	if !m.overridesFail {
+		return false, false
+	}
+	return m.failingLeaves[leaf.String()], true
+}
+
+func TestReorder(t *testing.T) {
+	testCases := []struct {
+		name           string
+		source         string
+		model          CostModel
+		expectedSource string
+	}{
+		{
+			name:           "cheap check first",
+			source:         `AND(MATCH(http.request.query, 1), CHECK(http.request.method == "GET"))`,
+			model:          CostModel{},
+			expectedSource: `AND(CHECK(http.request.method == "GET"), MATCH(http.request.query, 1))`,
+		},
+		{
+			name:           "match of body isn't moved",
+			source:         `AND(MATCH(http.request.body, 1), CHECK(http.request.method == "GET"))`,
+			model:          CostModel{},
+			expectedSource: `AND(MATCH(http.request.body, 1), CHECK(http.request.method == "GET"))`,
+		},
+		{
+			name:           "match of body is moved if errors are ignored",
+			source:         `AND(MATCH(http.request.body, 1), CHECK(http.request.method == "GET"))`,
+			model:          CostModel{ErrorPolicy: IgnoreErrors},
+			expectedSource: `AND(CHECK(http.request.method == "GET"), MATCH(http.request.body, 1))`,
+		},
+		{
+			name:           "cheap check first in OR",
+			source:         `OR(MATCH(http.request.headers.User-Agent, 1), EXISTS(http.request.headers.X-Token), CHECK(http.port == 80))`,
+			model:          CostModel{},
+			expectedSource: `OR(CHECK(http.port == 80), EXISTS(http.request.headers.X-Token), MATCH(http.request.headers.User-Agent, 1))`,
+		},
+		{
+			name:           "equal costs keep order",
+			source:         `AND(CHECK(http.port == 80), CHECK(http.host == "a"), CHECK(http.request.method == "GET"))`,
+			model:          CostModel{},
+			expectedSource: `AND(CHECK(http.port == 80), CHECK(http.host == "a"), CHECK(http.request.method == "GET"))`,
+		},
+		{
+			name: "failing operands are barriers",
+			source: `AND(MATCH(http.request.query, 1), CHECK(http.port == 80), CHECK(http.request.body.a > 1),` +
+				` MATCH(http.response.body, 1), MATCH(http.request.path, 1), CHECK(http.request.method == "GET"))`,
+			model: CostModel{ErrorPolicy: PreserveErrors},
+			expectedSource: `AND(CHECK(http.port == 80), MATCH(http.request.query, 1), CHECK(http.request.body.a > 1),` +
+				` MATCH(http.response.body, 1), CHECK(http.request.method == "GET"), MATCH(http.request.path, 1))`,
+		},
+		{
+			name: "failing operands are reordered",
+			source: `AND(MATCH(http.request.body, 1), CHECK(http.port == 80), CHECK(http.request.body.a > 1),` +
+				` MATCH(http.response.body, 1), CHECK(http.request.method == "GET"))`,
+			model: CostModel{ErrorPolicy: IgnoreErrors},
+			expectedSource: `AND(CHECK(http.port == 80), CHECK(http.request.method == "GET"), CHECK(http.request.body.a > 1),` +
+				` MATCH(http.request.body, 1), MATCH(http.response.body, 1))`,
+		},
+		{
+			name:           "nested",
+			source:         `OR(AND(MATCH(http.request.query, 1), EXISTS(http.options.A)), NOT(CHECK(http.port IN (80, 443))))`,
+			model:          CostModel{},
+			expectedSource: `OR(NOT(CHECK(http.port IN (80, 443))), AND(MATCH(http.request.query, 1), EXISTS(http.options.A)))`,
+		},
+		{
+			name:   "estimator",
+			source: `AND(CHECK(http.request.method == "GET"), MATCH(http.request.query, 1))`,
+			model: CreateCostModel(&costEstimatorManager{
+				ExecutionManager: CreateExecutionManager(nil),
+				costs:            map[TDataKey]float64{RequestMethodKey: 100},
+			}, nil, PreserveErrors),
+			expectedSource: `AND(MATCH(http.request.query, 1), CHECK(http.request.method == "GET"))`,
+		},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			node, nodeError := ParseRuleNode(currentTestCase.source)
+			assert.NoError(t, nodeError)
+			assert.Equal(t, currentTestCase.expectedSource, Reorder(node, currentTestCase.model).String())
+		})
+	}
+}
+
+func TestReorderWithLearnedSelectivity(t *testing.T) {
+	stats := CreateSelectivityStats()
+	expression, expressionError := ParseRuleWithOptions(
+		`OR(CHECK(http.request.method == "DELETE"), CHECK(http.request.method == "GET"))`,
+		CompileOptions{Stats: stats})
+	assert.NoError(t, expressionError)
+	manager := CreateExecutionManager(nil)
+	for _, method := range []string{"GET", "GET", "GET", "POST", "GET"} {
+		_, actualError := expression(context.Background(), &HttpData{Request: RequestData{Method: method}}, manager)
+		assert.NoError(t, actualError)
+	}
+	getNode, _ := ParseRuleNode(`CHECK(http.request.method == "GET")`)
+	deleteNode, _ := ParseRuleNode(`CHECK(http.request.method == "DELETE")`)
+	getSelectivity, getOk := stats.Selectivity(getNode)
+	assert.True(t, getOk)
+	assert.InDelta(t, 5.0/7.0, getSelectivity, 1e-9)
+	deleteSelectivity, deleteOk := stats.Selectivity(deleteNode)
+	assert.True(t, deleteOk)
+	assert.InDelta(t, 1.0/7.0, deleteSelectivity, 1e-9)
+	model := CostModel{Stats: stats}
+	orNode := CreateOrNode(deleteNode, getNode)
+	assert.Equal(t, `OR(CHECK(http.request.method == "GET"), CHECK(http.request.method == "DELETE"))`, Reorder(orNode, model).String())
+	andNode := CreateAndNode(getNode, deleteNode)
+	assert.Equal(t, `AND(CHECK(http.request.method == "DELETE"), CHECK(http.request.method == "GET"))`, Reorder(andNode, model).String())
+	assert.Less(t, model.EstimateCost(Reorder(andNode, model)), model.EstimateCost(andNode))
+}
+
+func TestReorderProperty(t *testing.T) {
+	someError := errors.New("some error")
+	optionNames := []string{"A", "B", "C", "D", "E"}
+	random := rand.New(rand.NewSource(7))
+	// only leaves with option E can fail
+	estimator := &costEstimatorManager{
+		costs:         map[TDataKey]float64{},
+		failingLeaves: map[string]bool{"EXISTS(http.options.E)": true, "MATCH(http.options.E, 1)": true},
+		overridesFail: true,
+	}
+	httpData := &HttpData{}
+	for iteration := 0; iteration < 300; iteration++ {
+		node := generateOptionsNode(random, optionNames, 3)
+		stats := CreateSelectivityStats()
+		for _, name := range optionNames {
+			path := CreateDataPathWithSimpleContent(OptionsKey, name)
+			for count := random.Intn(10); count > 0; count-- {
+				stats.Record(CreateExistsNode(path), random.Intn(3) == 0)
+				stats.Record(CreateMatchNode(path, 1), random.Intn(3) != 0)
+			}
+		}
+		for _, policy := range []ReorderErrorPolicy{PreserveErrors, IgnoreErrors} {
+			reorderedNode := Reorder(node, CostModel{Estimator: estimator, Stats: stats, ErrorPolicy: policy})
+			expression, expressionError := Compile(node)
+			reorderedExpression, reorderedExpressionError := Compile(reorderedNode)
+			if !assert.NoError(t, expressionError) || !assert.NoError(t, reorderedExpressionError) {
+				return
+			}
+			for dataIteration := 0; dataIteration < 8; dataIteration++ {
+				values := map[string]optionValue{}
+				for _, name := range optionNames {
+					values["exists "+name] = optionValue{result: random.Intn(2) == 0}
+					values["match "+name] = optionValue{result: random.Intn(2) == 0}
+				}
+				if policy == PreserveErrors && random.Intn(2) == 0 {
+					values["exists E"] = optionValue{err: someError}
+					values["match E"] = optionValue{err: someError}
+				}
+				controller := gomock.NewController(t)
+				mock := NewMockIExecutionManager(controller)
+				expectOptionValues(mock, values)
+				actualResult, actualError := expression(context.Background(), httpData, mock)
+				reorderedResult, reorderedError := reorderedExpression(context.Background(), httpData, mock)
+				description := fmt.Sprintf("%v -> %v, values %v", node, reorderedNode, values)
+				assert.Equal(t, actualError, reorderedError, description)
+				assert.Equal(t, actualResult, reorderedResult, description)
+				controller.Finish()
+			}
+		}
+	}
+}
+
+func TestReorderDefaultManagerProperty(t *testing.T) {
+	registry := CreatePatternRegistry()
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 1, Kind: SubstringPattern, Value: "x"}))
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 2, Kind: ExactPattern, Value: "GET"}))
+	manager := CreateExecutionManager(registry)
+	leafSources := []string{
+		`MATCH(http.request.body, 1)`,
+		`MATCH(http.request.body.b, 1)`,
+		`CHECK(http.request.body.a > 1)`,
+		`CHECK(http.request.method == "GET")`,
+		`MATCH(http.request.method, 2)`,
+		`MATCH(http.options, 1)`,
+		`MATCH(http.options.B, 1)`,
+		`EXISTS(http.options.A)`,
+		`MATCH(http.client.geoip.city, 1)`,
+		`MATCH(http, 1)`,
+		`MATCH(http.request.headers.X-Data.b, 1)`,
+		`CHECK(http.port == 80)`,
+	}
+	leaves := make([]Node, len(leafSources))
+	for index, source := range leafSources {
+		leaf, leafError := ParseRuleNode(source)
+		if !assert.NoError(t, leafError) {
+			return
+		}
+		leaves[index] = leaf
+	}
+	dataVariants := []*HttpData{
+		{
+			Request: RequestData{
+				Method:  "GET",
+				Body:    []byte(`{"a": null, "b": [null, "x"]}`),
+				Headers: map[string][]string{"X-Data": {`{"b": null}`}},
+			},
+			Options: map[string]any{"A": nil, "B": "x"},
+		},
+		{
+			Request: RequestData{
+				Method:  "POST",
+				Body:    []byte(`not json`),
+				Headers: map[string][]string{"X-Data": {`not json`}},
+			},
+			Options: map[string]any{"B": struct{}{}},
+		},
+		{
+			Port:    80,
+			Request: RequestData{Method: "GET", Body: []byte(`{"a": 2, "b": "x"}`)},
+			Client:  ClientData{GeoIp: &GeoIpData{City: "x"}},
+		},
+	}
+	random := rand.New(rand.NewSource(11))
+	for iteration := 0; iteration < 300; iteration++ {
+		node := generateNode(random, func() Node {
+			return leaves[random.Intn(len(leaves))]
+		}, 3)
+		stats := CreateSelectivityStats()
+		for _, leaf := range leaves {
+			for count := random.Intn(10); count > 0; count-- {
+				stats.Record(leaf, random.Intn(4) == 0)
+			}
+		}
+		reorderedNode := Reorder(node, CostModel{Stats: stats, ErrorPolicy: PreserveErrors})
+		expression, expressionError := Compile(node)
+		reorderedExpression, reorderedExpressionError := Compile(reorderedNode)
+		if !assert.NoError(t, expressionError) || !assert.NoError(t, reorderedExpressionError) {
+			return
+		}
+		for _, data := range dataVariants {
+			actualResult, actualError := expression(context.Background(), data, manager)
+			reorderedResult, reorderedError := reorderedExpression(context.Background(), data, manager)
+			description := fmt.Sprintf("%v -> %v, body %s", node, reorderedNode, data.Request.Body)
+			assert.Equal(t, actualError, reorderedError, description)
+			assert.Equal(t, actualResult, reorderedResult, description)
+		}
+	}
+}
@@ -1,5 +1,76 @@
 package expressiontree
 
+import "time"
+
+// HttpData is data of one HTTP request/response pair, fields correspond to main paths (see main_path_def.go)
 type HttpData struct {
-	// faked struct
+	Host        string
+	Protocol    string
+	Port        int
+	HttpVersion string
+	Timestamp   time.Time
+	Options     map[string]any
+	Client      ClientData
+	Request     RequestData
+	Response    ResponseData
+}
+
+type ClientData struct {
+	Id string
+	Ip string
+	// nil if GeoIP data is unavailable (e.g. there is no GeoIP database)
+	GeoIp     *GeoIpData
+	Os        OsData
+	Browser   BrowserData
+	BasicAuth BasicAuthData
+}
+
+type GeoIpData struct {
+	Country        string
+	CountryCode    string
+	City           string
+	Lat            float64
+	Lon            float64
+	AccuracyRadius int
+}
+
+type OsData struct {
+	Name    string
+	Version string
+}
+
+type BrowserData struct {
+	Name    string
+	Version string
+}
+
+type BasicAuthData struct {
+	Username string
+	Password string
+}
+
+type RequestData struct {
+	Id     string
+	Path   string
+	Paths  []string
+	Query  string
+	Method string
+	// raw body, JSON body is parsed for content path
+	Body    []byte
+	Get     map[string][]string
+	Post    map[string][]string
+	Headers map[string][]string
+	// time of request processing
+	Time    time.Duration
+	Cookies map[string][]string
+	Length  int64
+}
+
+type ResponseData struct {
+	// raw body, JSON body is parsed for content path
+	Body    []byte
+	Code    int
+	Source  string
+	Headers map[string][]string
+	Length  int64
 }
@@ -0,0 +1,282 @@
+package expressiontree
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultMaxBodySize = 1 << 20
+
+type HttpDataOptions struct {
+	// max size of captured request/response body, 0 - DefaultMaxBodySize, negative - body isn't captured
+	MaxBodySize int64
+	// header with request id, "X-Request-Id" if empty
+	RequestIdHeader string
+	// value of http.response.source
+	ResponseSource string
+}
+
+func (o *HttpDataOptions) maxBodySize() int64 {
+	if o.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return o.MaxBodySize
+}
+
+// CreateHttpDataFromRequest fills HttpData from request.
+// Body is captured up to MaxBodySize and request.Body is replaced so that it can be read again.
+// Post values are decoded from captured body (urlencoded and multipart forms) only if body is captured completely.
+func CreateHttpDataFromRequest(request *http.Request, options HttpDataOptions) (*HttpData, error) {
+	body, complete, bodyError := captureBody(&request.Body, options.maxBodySize())
+	if bodyError != nil {
+		return nil, bodyError
+	}
+	host, port := splitRequestHost(request)
+	clientIp, _, splitError := net.SplitHostPort(request.RemoteAddr)
+	if splitError != nil {
+		clientIp = request.RemoteAddr
+	}
+	requestIdHeader := options.RequestIdHeader
+	if len(requestIdHeader) == 0 {
+		requestIdHeader = "X-Request-Id"
+	}
+	data := &HttpData{
+		Host:        host,
+		Protocol:    requestProtocol(request),
+		Port:        port,
+		HttpVersion: request.Proto,
+		Timestamp:   time.Now(),
+		Options:     map[string]any{},
+		Client: ClientData{
+			Ip:        clientIp,
+			BasicAuth: requestBasicAuth(request),
+		},
+		Request: RequestData{
+			Id:      request.Header.Get(requestIdHeader),
+			Path:    request.URL.Path,
+			Paths:   splitRequestPath(request.URL.Path),
+			Query:   request.URL.RawQuery,
+			Method:  request.Method,
+			Body:    body,
+			Get:     request.URL.Query(),
+			Post:    map[string][]string{},
+			Headers: request.Header.Clone(),
+			Cookies: requestCookies(request),
+			Length:  bodyLength(request.ContentLength, body, complete),
+		},
+	}
+	if complete {
+		data.Request.Post = decodePostValues(request.Header.Get("Content-Type"), body)
+	}
+	return data, nil
+}
+
+// FillResponse fills response part of HttpData, response.Body is replaced so that it can be read again
+func (d *HttpData) FillResponse(response *http.Response, options HttpDataOptions) error {
+	body, complete, bodyError := captureBody(&response.Body, options.maxBodySize())
+	if bodyError != nil {
+		return bodyError
+	}
+	d.Response = ResponseData{
+		Body:    body,
+		Code:    response.StatusCode,
+		Source:  options.ResponseSource,
+		Headers: response.Header.Clone(),
+		Length:  bodyLength(response.ContentLength, body, complete),
+	}
+	return nil
+}
+
+// FillResponseFromRecorder fills response part of HttpData and request time from ResponseRecorder
+func (d *HttpData) FillResponseFromRecorder(recorder *ResponseRecorder, options HttpDataOptions) {
+	d.Response = ResponseData{
+		Body:    recorder.body.Bytes(),
+		Code:    recorder.StatusCode(),
+		Source:  options.ResponseSource,
+		Headers: recorder.Header().Clone(),
+		Length:  recorder.length,
+	}
+	d.Request.Time = time.Since(recorder.start)
+}
+
+// ResponseRecorder is http.ResponseWriter which passes data to underlying writer and captures response
+type ResponseRecorder struct {
+	writer      http.ResponseWriter
+	start       time.Time
+	code        int
+	body        bytes.Buffer
+	maxBodySize int64
+	length      int64
+}
+
+func CreateResponseRecorder(writer http.ResponseWriter, options HttpDataOptions) *ResponseRecorder {
+	return &ResponseRecorder{writer: writer, start: time.Now(), maxBodySize: options.maxBodySize()}
+}
+
+func (r *ResponseRecorder) Header() http.Header {
+	return r.writer.Header()
+}
+
+func (r *ResponseRecorder) WriteHeader(statusCode int) {
+	if r.code == 0 {
+		r.code = statusCode
+	}
+	r.writer.WriteHeader(statusCode)
+}
+
+func (r *ResponseRecorder) Write(data []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	if rest := r.maxBodySize - int64(r.body.Len()); rest > 0 {
+		r.body.Write(data[0:min(int64(len(data)), rest)])
+	}
+	size, writeError := r.writer.Write(data)
+	r.length += int64(size)
+	return size, writeError
+}
+
+func (r *ResponseRecorder) Flush() {
+	if flusher, isFlusher := r.writer.(http.Flusher); isFlusher {
+		flusher.Flush()
+	}
+}
+
+func (r *ResponseRecorder) StatusCode() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
+
+// captureBody reads up to maxSize bytes of body and replaces body by reader of captured and rest data
+func captureBody(body *io.ReadCloser, maxSize int64) ([]byte, bool, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, true, nil
+	}
+	if maxSize < 0 {
+		return nil, false, nil
+	}
+	captured, readError := io.ReadAll(io.LimitReader(*body, maxSize+1))
+	if readError != nil {
+		return nil, false, readError
+	}
+	complete := int64(len(captured)) <= maxSize
+	*body = &replayBody{Reader: io.MultiReader(bytes.NewReader(captured), *body), closer: *body}
+	if !complete {
+		captured = captured[0:maxSize]
+	}
+	return captured, complete, nil
+}
+
+type replayBody struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (b *replayBody) Close() error {
+	return b.closer.Close()
+}
+
+func bodyLength(contentLength int64, body []byte, complete bool) int64 {
+	if contentLength < 0 && complete {
+		return int64(len(body))
+	}
+	return max(contentLength, 0)
+}
+
+func requestProtocol(request *http.Request) string {
+	if request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func splitRequestHost(request *http.Request) (string, int) {
+	host, portValue, splitError := net.SplitHostPort(request.Host)
+	if splitError == nil {
+		port, convertError := strconv.Atoi(portValue)
+		if convertError == nil {
+			return host, port
+		}
+	}
+	if request.TLS != nil {
+		return request.Host, 443
+	}
+	return request.Host, 80
+}
+
+func splitRequestPath(path string) []string {
+	paths := make([]string, 0)
+	for _, part := range strings.Split(path, "/") {
+		if len(part) > 0 {
+			paths = append(paths, part)
+		}
+	}
+	return paths
+}
+
+func requestBasicAuth(request *http.Request) BasicAuthData {
+	username, password, exists := request.BasicAuth()
+	if !exists {
+		return BasicAuthData{}
+	}
+	return BasicAuthData{Username: username, Password: password}
+}
+
+func requestCookies(request *http.Request) map[string][]string {
+	cookies := map[string][]string{}
+	for _, cookie := range request.Cookies() {
+		cookies[cookie.Name] = append(cookies[cookie.Name], cookie.Value)
+	}
+	return cookies
+}
+
+// only values of forms are decoded, files of multipart form are skipped
+func decodePostValues(contentType string, body []byte) map[string][]string {
+	mediaType, parameters, parseError := mime.ParseMediaType(contentType)
+	if parseError != nil {
+		return map[string][]string{}
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, queryError := url.ParseQuery(string(body))
+		if queryError != nil {
+			return map[string][]string{}
+		}
+		return values
+	case "multipart/form-data":
+		return decodeMultipartValues(body, parameters["boundary"])
+	default:
+		return map[string][]string{}
+	}
+}
+
+func decodeMultipartValues(body []byte, boundary string) map[string][]string {
+	values := map[string][]string{}
+	if len(boundary) == 0 {
+		return values
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, partError := reader.NextPart()
+		if partError != nil {
+			return values
+		}
+		if len(part.FileName()) == 0 && len(part.FormName()) > 0 {
+			value, readError := io.ReadAll(part)
+			if readError == nil {
+				values[part.FormName()] = append(values[part.FormName()], string(value))
+			}
+		}
+		_ = part.Close()
+	}
+}
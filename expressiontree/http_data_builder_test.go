@@ -0,0 +1,117 @@
+package expressiontree
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHttpDataFromRequest(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "http://example.com:8080/api//v1/users?page=2&tag=a&tag=b",
+		strings.NewReader("comment=hello&comment=world&name=bob"))
+	request.RemoteAddr = "10.0.0.1:54321"
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("X-Request-Id", "request-1")
+	request.SetBasicAuth("admin", "secret")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	data, dataError := CreateHttpDataFromRequest(request, HttpDataOptions{})
+	assert.NoError(t, dataError)
+	assert.Equal(t, "example.com", data.Host)
+	assert.Equal(t, 8080, data.Port)
+	assert.Equal(t, "http", data.Protocol)
+	assert.Equal(t, "HTTP/1.1", data.HttpVersion)
+	assert.Equal(t, "10.0.0.1", data.Client.Ip)
+	assert.Equal(t, BasicAuthData{Username: "admin", Password: "secret"}, data.Client.BasicAuth)
+	assert.Equal(t, "request-1", data.Request.Id)
+	assert.Equal(t, "/api//v1/users", data.Request.Path)
+	assert.Equal(t, []string{"api", "v1", "users"}, data.Request.Paths)
+	assert.Equal(t, "page=2&tag=a&tag=b", data.Request.Query)
+	assert.Equal(t, http.MethodPost, data.Request.Method)
+	assert.Equal(t, map[string][]string{"page": {"2"}, "tag": {"a", "b"}}, data.Request.Get)
+	assert.Equal(t, map[string][]string{"comment": {"hello", "world"}, "name": {"bob"}}, data.Request.Post)
+	assert.Equal(t, map[string][]string{"session": {"s1"}}, data.Request.Cookies)
+	assert.Equal(t, []string{"application/x-www-form-urlencoded"}, data.Request.Headers["Content-Type"])
+	assert.Equal(t, int64(36), data.Request.Length)
+	// body can be read again
+	body, readError := io.ReadAll(request.Body)
+	assert.NoError(t, readError)
+	assert.Equal(t, "comment=hello&comment=world&name=bob", string(body))
+	assert.Equal(t, body, data.Request.Body)
+}
+
+func TestCreateHttpDataFromRequestBodyLimit(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader("comment=hello"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	data, dataError := CreateHttpDataFromRequest(request, HttpDataOptions{MaxBodySize: 5})
+	assert.NoError(t, dataError)
+	assert.Equal(t, "https", data.Protocol)
+	assert.Equal(t, 443, data.Port)
+	assert.Equal(t, []byte("comme"), data.Request.Body)
+	assert.Empty(t, data.Request.Post)
+	assert.Equal(t, int64(13), data.Request.Length)
+	body, readError := io.ReadAll(request.Body)
+	assert.NoError(t, readError)
+	assert.Equal(t, "comment=hello", string(body))
+}
+
+func TestCreateHttpDataFromMultipartRequest(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := multipart.NewWriter(buffer)
+	assert.NoError(t, writer.WriteField("name", "bob"))
+	fileWriter, fileError := writer.CreateFormFile("file", "data.txt")
+	assert.NoError(t, fileError)
+	_, _ = fileWriter.Write([]byte("file content"))
+	assert.NoError(t, writer.Close())
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/upload", buffer)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	data, dataError := CreateHttpDataFromRequest(request, HttpDataOptions{})
+	assert.NoError(t, dataError)
+	assert.Equal(t, map[string][]string{"name": {"bob"}}, data.Request.Post)
+}
+
+func TestHttpDataFillResponse(t *testing.T) {
+	response := &http.Response{
+		StatusCode:    http.StatusServiceUnavailable,
+		Header:        http.Header{"Server": {"nginx"}},
+		Body:          io.NopCloser(strings.NewReader(`{"error": "unavailable"}`)),
+		ContentLength: -1,
+	}
+	data := &HttpData{}
+	assert.NoError(t, data.FillResponse(response, HttpDataOptions{ResponseSource: "upstream"}))
+	assert.Equal(t, http.StatusServiceUnavailable, data.Response.Code)
+	assert.Equal(t, "upstream", data.Response.Source)
+	assert.Equal(t, []string{"nginx"}, data.Response.Headers["Server"])
+	assert.Equal(t, int64(24), data.Response.Length)
+	manager := CreateExecutionManager(nil)
+	result, resultError := manager.RecursiveCheckResponseBody(context.Background(), func(value any) (bool, error) {
+		return value == "unavailable", nil
+	}, CreateSimpleContentPath("error"), data)
+	assert.NoError(t, resultError)
+	assert.True(t, result)
+	body, readError := io.ReadAll(response.Body)
+	assert.NoError(t, readError)
+	assert.Equal(t, `{"error": "unavailable"}`, string(body))
+}
+
+func TestHttpDataFillResponseFromRecorder(t *testing.T) {
+	writer := httptest.NewRecorder()
+	recorder := CreateResponseRecorder(writer, HttpDataOptions{MaxBodySize: 4})
+	recorder.Header().Set("Location", "/login")
+	recorder.WriteHeader(http.StatusFound)
+	_, _ = recorder.Write([]byte("redirect"))
+	data := &HttpData{}
+	data.FillResponseFromRecorder(recorder, HttpDataOptions{})
+	assert.Equal(t, http.StatusFound, data.Response.Code)
+	assert.Equal(t, []byte("redi"), data.Response.Body)
+	assert.Equal(t, int64(8), data.Response.Length)
+	assert.Equal(t, []string{"/login"}, data.Response.Headers["Location"])
+	assert.Equal(t, "redirect", writer.Body.String())
+	assert.Equal(t, http.StatusFound, writer.Code)
+}
@@ -0,0 +1,242 @@
+package expressiontree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Values of HttpData used by ExecutionChecker and ExecutionMatcher.
+// Recursive values are all scalar values (leaves) of section, map keys are visited in sorted order.
+// Content path of get/post/headers/cookies: first part is name (headers name is case-insensitive),
+// other parts are path inside JSON value. Content path of body is path inside JSON body.
+// Non-JSON body or value is one string leaf.
+
+var noGeoIpDataError = errors.New("no geoip data")
+
+func httpDataValues(data *HttpData) []any {
+	values := []any{data.Host, data.Protocol, data.Port, data.HttpVersion, data.Timestamp}
+	values = append(values, optionsValues(data)...)
+	values = append(values, clientValues(data)...)
+	values = append(values, requestValues(data)...)
+	return append(values, responseValues(data)...)
+}
+
+func optionsValues(data *HttpData) []any {
+	return appendLeaves(nil, data.Options)
+}
+
+func optionValues(name string, data *HttpData) []any {
+	value, exists := data.Options[name]
+	if !exists {
+		return nil
+	}
+	return appendLeaves(nil, value)
+}
+
+func clientValues(data *HttpData) []any {
+	values := []any{data.Client.Id, data.Client.Ip}
+	if data.Client.GeoIp != nil {
+		geoIp, _ := geoIpValues(data)
+		values = append(values, geoIp...)
+	}
+	values = append(values, osValues(data)...)
+	values = append(values, browserValues(data)...)
+	return append(values, basicAuthValues(data)...)
+}
+
+func geoIpValues(data *HttpData) ([]any, error) {
+	geoIp := data.Client.GeoIp
+	if geoIp == nil {
+		return nil, noGeoIpDataError
+	}
+	return []any{geoIp.Country, geoIp.CountryCode, geoIp.City, geoIp.Lat, geoIp.Lon, geoIp.AccuracyRadius}, nil
+}
+
+func geoIpFieldValues(data *HttpData, field func(geoIp *GeoIpData) any) ([]any, error) {
+	if data.Client.GeoIp == nil {
+		return nil, noGeoIpDataError
+	}
+	return []any{field(data.Client.GeoIp)}, nil
+}
+
+func osValues(data *HttpData) []any {
+	return []any{data.Client.Os.Name, data.Client.Os.Version}
+}
+
+func browserValues(data *HttpData) []any {
+	return []any{data.Client.Browser.Name, data.Client.Browser.Version}
+}
+
+func basicAuthValues(data *HttpData) []any {
+	return []any{data.Client.BasicAuth.Username, data.Client.BasicAuth.Password}
+}
+
+func requestValues(data *HttpData) []any {
+	request := &data.Request
+	values := []any{request.Id, request.Path}
+	values = append(values, requestPathsValues(data)...)
+	values = append(values, request.Query, request.Method)
+	values = append(values, bodyValues(request.Body, CreateEmptyContentPath())...)
+	values = append(values, multiMapValues(request.Get)...)
+	values = append(values, multiMapValues(request.Post)...)
+	values = append(values, multiMapValues(request.Headers)...)
+	values = append(values, multiMapValues(request.Cookies)...)
+	return append(values, request.Time, request.Length)
+}
+
+func requestPathsValues(data *HttpData) []any {
+	values := make([]any, 0, len(data.Request.Paths))
+	for _, value := range data.Request.Paths {
+		values = append(values, value)
+	}
+	return values
+}
+
+func requestPathsElementValues(index int, data *HttpData) []any {
+	if index < 0 || index >= len(data.Request.Paths) {
+		return nil
+	}
+	return []any{data.Request.Paths[index]}
+}
+
+func responseValues(data *HttpData) []any {
+	response := &data.Response
+	values := bodyValues(response.Body, CreateEmptyContentPath())
+	values = append(values, response.Code, response.Source)
+	values = append(values, multiMapValues(response.Headers)...)
+	return append(values, response.Length)
+}
+
+func bodyValues(body []byte, path ContentPath) []any {
+	if len(body) == 0 {
+		return nil
+	}
+	value, exists := navigateContent(decodeContent(body), path.Parts)
+	if !exists {
+		return nil
+	}
+	return appendLeaves(nil, value)
+}
+
+func multiMapValues(source map[string][]string) []any {
+	values := make([]any, 0)
+	for _, key := range sortedKeys(source) {
+		for _, value := range source[key] {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func multiMapContentValues(source map[string][]string, path ContentPath, ignoreCase bool) []any {
+	if path.IsEmpty() {
+		return multiMapValues(source)
+	}
+	values := make([]any, 0)
+	for _, value := range lookupMultiMap(source, path.Parts[0], ignoreCase) {
+		if path.IsSimple() {
+			values = append(values, value)
+			continue
+		}
+		content, exists := navigateContent(decodeContent([]byte(value)), path.Parts[1:])
+		if exists {
+			values = appendLeaves(values, content)
+		}
+	}
+	return values
+}
+
+func multiMapContentExists(source map[string][]string, path ContentPath, ignoreCase bool) bool {
+	if path.IsEmpty() {
+		return false
+	}
+	for _, value := range lookupMultiMap(source, path.Parts[0], ignoreCase) {
+		if path.IsSimple() {
+			return true
+		}
+		if _, exists := navigateContent(decodeContent([]byte(value)), path.Parts[1:]); exists {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupMultiMap(source map[string][]string, name string, ignoreCase bool) []string {
+	if values, exists := source[name]; exists || !ignoreCase {
+		return values
+	}
+	for _, key := range sortedKeys(source) {
+		if strings.EqualFold(key, name) {
+			return source[key]
+		}
+	}
+	return nil
+}
+
+// JSON content is decoded (numbers as json.Number), otherwise content is string
+func decodeContent(content []byte) any {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid(trimmed) {
+		return string(content)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var result any
+	if decodeError := decoder.Decode(&result); decodeError != nil {
+		return string(content)
+	}
+	return result
+}
+
+func navigateContent(content any, parts []string) (any, bool) {
+	current := content
+	for _, part := range parts {
+		switch typedValue := current.(type) {
+		case map[string]any:
+			next, exists := typedValue[part]
+			if !exists {
+				return nil, false
+			}
+			current = next
+		case []any:
+			index, convertError := strconv.Atoi(part)
+			if convertError != nil || index < 0 || index >= len(typedValue) {
+				return nil, false
+			}
+			current = typedValue[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func appendLeaves(values []any, content any) []any {
+	switch typedValue := content.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(typedValue) {
+			values = appendLeaves(values, typedValue[key])
+		}
+		return values
+	case []any:
+		for _, item := range typedValue {
+			values = appendLeaves(values, item)
+		}
+		return values
+	default:
+		return append(values, content)
+	}
+}
+
+func sortedKeys[V any](source map[string]V) []string {
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -50,3 +50,62 @@ const (
 	ResponseHeadersKey
 	ResponseLengthKey
 )
+
+// names of main paths in rule language
+var dataKeyNames = map[TDataKey]string{
+	HttpDataKey:            "http",
+	HttpDataHostKey:        "http.host",
+	HttpDataProtocolKey:    "http.protocol",
+	HttpDataPortKey:        "http.port",
+	HttpDataHttpVersionKey: "http.http_version",
+	HttpDataTimestampKey:   "http.timestamp",
+	OptionsKey:             "http.options",
+	ClientKey:              "http.client",
+	ClientIdKey:            "http.client.id",
+	ClientIpKey:            "http.client.ip",
+	GeoIpKey:               "http.client.geoip",
+	GeoIpCountryKey:        "http.client.geoip.country",
+	GeoIpCountryCodeKey:    "http.client.geoip.country_code",
+	GeoIpCityKey:           "http.client.geoip.city",
+	GeoIpLatKey:            "http.client.geoip.lat",
+	GeoIpLonKey:            "http.client.geoip.lon",
+	GeoIpAccuracyRadiusKey: "http.client.geoip.accuracy_radius",
+	OsKey:                  "http.client.os",
+	OsNameKey:              "http.client.os.name",
+	OsVersionKey:           "http.client.os.version",
+	BrowserKey:             "http.client.browser",
+	BrowserNameKey:         "http.client.browser.name",
+	BrowserVersionKey:      "http.client.browser.version",
+	BasicAuthKey:           "http.client.basic_auth",
+	BasicAuthUsernameKey:   "http.client.basic_auth.username",
+	BasicAuthPasswordKey:   "http.client.basic_auth.password",
+	RequestKey:             "http.request",
+	RequestIdKey:           "http.request.id",
+	RequestPathKey:         "http.request.path",
+	RequestPathsKey:        "http.request.paths",
+	RequestQueryKey:        "http.request.query",
+	RequestMethodKey:       "http.request.method",
+	RequestBodyKey:         "http.request.body",
+	RequestGetKey:          "http.request.get",
+	RequestPostKey:         "http.request.post",
+	RequestHeadersKey:      "http.request.headers",
+	RequestTimeKey:         "http.request.time",
+	RequestCookiesKey:      "http.request.cookies",
+	RequestLengthKey:       "http.request.length",
+	ResponseKey:            "http.response",
+	ResponseBodyKey:        "http.response.body",
+	ResponseCodeKey:        "http.response.code",
+	ResponseSourceKey:      "http.response.source",
+	ResponseHeadersKey:     "http.response.headers",
+	ResponseLengthKey:      "http.response.length",
+}
+
+var dataKeysByName = createDataKeysByName()
+
+func createDataKeysByName() map[string]TDataKey {
+	result := make(map[string]TDataKey, len(dataKeyNames))
+	for key, name := range dataKeyNames {
+		result[name] = key
+	}
+	return result
+}
@@ -0,0 +1,206 @@
+package expressiontree
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+const (
+	// StringArgument - argument must be string literal
+	StringArgument = "string"
+	// NumberArgument - argument must be number literal, it is passed to operator as float64
+	NumberArgument = "number"
+	// BoolArgument - argument must be true or false
+	BoolArgument = "bool"
+	// AnyArgument - any literal including null, numbers are passed to operator as float64
+	AnyArgument = "any"
+)
+
+var badOperatorError = errors.New("bad operator")
+var duplicateOperatorError = errors.New("duplicate operator")
+var unknownOperatorError = errors.New("unknown operator")
+
+// names of custom operators are upper case words, so they look like built-in operations
+var operatorNameExpression = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// words of rule language (logical operators, parts of operations and literals) can't be names of operators
+var reservedOperatorNames = map[string]bool{
+	"AND":   true,
+	"OR":    true,
+	"NOT":   true,
+	"IN":    true,
+	"TRUE":  true,
+	"FALSE": true,
+	"NULL":  true,
+}
+
+// OperatorFunc checks value of path (as predicate of CHECK), arguments are literals of operator in rule
+type OperatorFunc func(value any, arguments []any) (bool, error)
+
+// OperatorDefinition - custom operator of CHECK(PATH NAME(LITERAL, ...)), Arguments are kinds of arguments
+// (StringArgument, NumberArgument, BoolArgument, AnyArgument). If Variadic is true, the last kind is used
+// for any count of the rest arguments (including zero).
+type OperatorDefinition struct {
+	Name      string
+	Arguments []string
+	Variadic  bool
+	Func      OperatorFunc
+}
+
+// OperatorRegistry stores custom operators by name, it is safe for concurrent use
+type OperatorRegistry struct {
+	mutex     sync.RWMutex
+	operators map[string]OperatorDefinition
+}
+
+func CreateOperatorRegistry() *OperatorRegistry {
+	return &OperatorRegistry{operators: map[string]OperatorDefinition{}}
+}
+
+func (r *OperatorRegistry) Add(definition OperatorDefinition) error {
+	if validateError := validateOperator(definition); validateError != nil {
+		return fmt.Errorf("operator %q: %w", definition.Name, validateError)
+	}
+	definition.Arguments = append([]string(nil), definition.Arguments...)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.operators[definition.Name]; exists {
+		return fmt.Errorf("operator %q: %w", definition.Name, duplicateOperatorError)
+	}
+	r.operators[definition.Name] = definition
+	return nil
+}
+
+// Has returns true if operator is registered, r may be nil
+func (r *OperatorRegistry) Has(name string) bool {
+	if r == nil {
+		return false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	_, exists := r.operators[name]
+	return exists
+}
+
+// Names returns sorted names of operators, r may be nil
+func (r *OperatorRegistry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.operators))
+	for name := range r.operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateOperator(definition OperatorDefinition) error {
+	if !operatorNameExpression.MatchString(definition.Name) {
+		return fmt.Errorf("%w: name must be upper case word", badOperatorError)
+	}
+	if _, isBuiltIn := ruleOperations[definition.Name]; isBuiltIn {
+		return fmt.Errorf("%w: name of built-in operation", badOperatorError)
+	}
+	if reservedOperatorNames[definition.Name] {
+		return fmt.Errorf("%w: reserved word", badOperatorError)
+	}
+	if definition.Func == nil {
+		return fmt.Errorf("%w: no implementation", badOperatorError)
+	}
+	if definition.Variadic && len(definition.Arguments) == 0 {
+		return fmt.Errorf("%w: variadic operator without argument kind", badOperatorError)
+	}
+	for _, kind := range definition.Arguments {
+		switch kind {
+		case StringArgument, NumberArgument, BoolArgument, AnyArgument:
+		default:
+			return fmt.Errorf("%w: unknown argument kind %q", badOperatorError, kind)
+		}
+	}
+	return nil
+}
+
+// predicate validates arguments of operator and creates predicate of CHECK, it is used at compile time
+func (r *OperatorRegistry) predicate(name string, argument any) (Predicate, error) {
+	if r == nil {
+		return nil, fmt.Errorf("operator %q: %w", name, unknownOperatorError)
+	}
+	r.mutex.RLock()
+	definition, exists := r.operators[name]
+	r.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("operator %q: %w", name, unknownOperatorError)
+	}
+	arguments, argumentsError := definition.convertArguments(argument)
+	if argumentsError != nil {
+		return nil, fmt.Errorf("operator %q: %w", name, argumentsError)
+	}
+	return func(value any) (bool, error) {
+		return definition.Func(value, arguments)
+	}, nil
+}
+
+// convertArguments checks count and kinds of arguments, numbers are converted to float64
+func (d OperatorDefinition) convertArguments(argument any) ([]any, error) {
+	items, isList := argument.([]any)
+	if !isList && argument != nil {
+		return nil, badArgumentTypeError
+	}
+	fixedCount := len(d.Arguments)
+	if d.Variadic {
+		fixedCount--
+	}
+	if len(items) < fixedCount || (!d.Variadic && len(items) > fixedCount) {
+		return nil, fmt.Errorf("%w: %d arguments instead of %d", badArgsError, len(items), fixedCount)
+	}
+	arguments := make([]any, 0, len(items))
+	for index, item := range items {
+		kind := d.Arguments[min(index, len(d.Arguments)-1)]
+		converted, convertError := convertOperatorArgument(kind, item)
+		if convertError != nil {
+			return nil, fmt.Errorf("argument %d: %w", index+1, convertError)
+		}
+		arguments = append(arguments, converted)
+	}
+	return arguments, nil
+}
+
+func convertOperatorArgument(kind string, item any) (any, error) {
+	switch value := item.(type) {
+	case nil:
+		if kind == AnyArgument {
+			return nil, nil
+		}
+	case bool:
+		if kind == BoolArgument || kind == AnyArgument {
+			return value, nil
+		}
+	case string:
+		if kind == StringArgument || kind == AnyArgument {
+			return value, nil
+		}
+	default:
+		if kind == NumberArgument || kind == AnyArgument {
+			number, numberError := toNumber(value)
+			if numberError != nil {
+				return nil, badArgumentTypeError
+			}
+			return number, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is expected", badArgumentTypeError, kind)
+}
+
+// checkPredicate creates predicate of built-in operation or custom operator of node
+func checkPredicate(node *CheckNode, operators *OperatorRegistry) (Predicate, error) {
+	if node.Operation == OperationCustom {
+		return operators.predicate(node.Operator, node.Argument)
+	}
+	return parsePredicate(node.Operation, node.Argument)
+}
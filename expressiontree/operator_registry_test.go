@@ -0,0 +1,220 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestOperators creates DISPOSABLE() (email of disposable domain), DOMAIN_IN(domain, ...)
+// and LONGER(number) (string is longer than number)
+func createTestOperators(t *testing.T) *OperatorRegistry {
+	t.Helper()
+	emailDomain := func(value any) (string, error) {
+		email, isString := value.(string)
+		if !isString {
+			return "", fmt.Errorf("%w: email must be string", coercionError)
+		}
+		return email[strings.LastIndex(email, "@")+1:], nil
+	}
+	operators := CreateOperatorRegistry()
+	assert.NoError(t, operators.Add(OperatorDefinition{Name: "DISPOSABLE", Func: func(value any, arguments []any) (bool, error) {
+		domain, domainError := emailDomain(value)
+		return domain == "mailinator.com" || domain == "10minutemail.com", domainError
+	}}))
+	assert.NoError(t, operators.Add(OperatorDefinition{Name: "DOMAIN_IN", Arguments: []string{StringArgument}, Variadic: true,
+		Func: func(value any, arguments []any) (bool, error) {
+			domain, domainError := emailDomain(value)
+			for _, argument := range arguments {
+				if argument == domain {
+					return true, domainError
+				}
+			}
+			return false, domainError
+		}}))
+	assert.NoError(t, operators.Add(OperatorDefinition{Name: "LONGER", Arguments: []string{NumberArgument},
+		Func: func(value any, arguments []any) (bool, error) {
+			text, textError := toString(value)
+			return float64(len(text)) > arguments[0].(float64), textError
+		}}))
+	return operators
+}
+
+func TestOperatorRegistryAdd(t *testing.T) {
+	check := func(value any, arguments []any) (bool, error) { return true, nil }
+	testCases := []struct {
+		name       string
+		definition OperatorDefinition
+		expected   error
+	}{
+		{name: "valid", definition: OperatorDefinition{Name: "IS_IP4", Arguments: []string{AnyArgument, BoolArgument}, Func: check}},
+		{name: "duplicate", definition: OperatorDefinition{Name: "IS_IP4", Func: check}, expected: duplicateOperatorError},
+		{name: "lower case", definition: OperatorDefinition{Name: "is_ip4", Func: check}, expected: badOperatorError},
+		{name: "symbols", definition: OperatorDefinition{Name: "IS-IP4", Func: check}, expected: badOperatorError},
+		{name: "built-in", definition: OperatorDefinition{Name: "CONTAINS", Func: check}, expected: badOperatorError},
+		{name: "not", definition: OperatorDefinition{Name: "NOT", Func: check}, expected: badOperatorError},
+		{name: "and", definition: OperatorDefinition{Name: "AND", Func: check}, expected: badOperatorError},
+		{name: "or", definition: OperatorDefinition{Name: "OR", Func: check}, expected: badOperatorError},
+		{name: "in", definition: OperatorDefinition{Name: "IN", Func: check}, expected: badOperatorError},
+		{name: "true", definition: OperatorDefinition{Name: "TRUE", Func: check}, expected: badOperatorError},
+		{name: "false", definition: OperatorDefinition{Name: "FALSE", Func: check}, expected: badOperatorError},
+		{name: "null", definition: OperatorDefinition{Name: "NULL", Func: check}, expected: badOperatorError},
+		{name: "no implementation", definition: OperatorDefinition{Name: "IS_IP6"}, expected: badOperatorError},
+		{name: "unknown kind", definition: OperatorDefinition{Name: "IS_IP6", Arguments: []string{"list"}, Func: check}, expected: badOperatorError},
+		{name: "variadic without kind", definition: OperatorDefinition{Name: "IS_IP6", Variadic: true, Func: check}, expected: badOperatorError},
+	}
+	operators := CreateOperatorRegistry()
+	for _, testCase := range testCases {
+		addError := operators.Add(testCase.definition)
+		if testCase.expected == nil {
+			assert.NoError(t, addError, testCase.name)
+		} else {
+			assert.ErrorIs(t, addError, testCase.expected, testCase.name)
+		}
+	}
+	assert.Equal(t, []string{"IS_IP4"}, operators.Names())
+	assert.True(t, operators.Has("IS_IP4"))
+	assert.False(t, operators.Has("IS_IP6"))
+	var empty *OperatorRegistry
+	assert.False(t, empty.Has("IS_IP4"))
+	assert.Empty(t, empty.Names())
+}
+
+func TestOperatorRules(t *testing.T) {
+	operators := createTestOperators(t)
+	testCases := []struct {
+		source         string
+		options        map[string]any
+		expected       bool
+		expectedError  error
+		expectedSource string
+	}{
+		{
+			source:         "CHECK(http.options.Email DISPOSABLE())",
+			options:        map[string]any{"Email": "bob@mailinator.com"},
+			expected:       true,
+			expectedSource: "CHECK(http.options.Email DISPOSABLE())",
+		},
+		{
+			source:         "CHECK( http.options.Email  DISPOSABLE ( ) )",
+			options:        map[string]any{"Email": "bob@example.com"},
+			expected:       false,
+			expectedSource: "CHECK(http.options.Email DISPOSABLE())",
+		},
+		{
+			source:         `CHECK(http.options.Email DOMAIN_IN("example.com", "example.org"))`,
+			options:        map[string]any{"Email": "bob@example.org"},
+			expected:       true,
+			expectedSource: `CHECK(http.options.Email DOMAIN_IN("example.com", "example.org"))`,
+		},
+		{
+			source:         `AND(EXISTS(http.options.Email), NOT(CHECK(http.options.Email DOMAIN_IN())))`,
+			options:        map[string]any{"Email": "bob@example.org"},
+			expected:       true,
+			expectedSource: `AND(EXISTS(http.options.Email), NOT(CHECK(http.options.Email DOMAIN_IN())))`,
+		},
+		{
+			source:         "CHECK(http.options.Email LONGER(10))",
+			options:        map[string]any{"Email": "bob@example.org"},
+			expected:       true,
+			expectedSource: "CHECK(http.options.Email LONGER(10))",
+		},
+		{
+			source:         "CHECK(http.options.Email DISPOSABLE())",
+			options:        map[string]any{"Email": 1},
+			expectedError:  coercionError,
+			expectedSource: "CHECK(http.options.Email DISPOSABLE())",
+		},
+	}
+	for _, backend := range []CompileBackend{ClosureBackend, BytecodeBackend} {
+		options := CompileOptions{Operators: operators, Backend: backend}
+		for _, testCase := range testCases {
+			node, nodeError := ParseRuleNodeWithOptions(testCase.source, options)
+			if !assert.NoError(t, nodeError, testCase.source) {
+				continue
+			}
+			assert.Equal(t, testCase.expectedSource, node.String())
+			predicate, predicateError := CompileWithOptions(node, options)
+			assert.NoError(t, predicateError, testCase.source)
+			data := &HttpData{Options: testCase.options}
+			result, err := predicate(context.Background(), data, CreateExecutionManager(nil))
+			explanation, explainError := ExplainWithOptions(context.Background(), node, data, CreateExecutionManager(nil), options)
+			assert.NoError(t, explainError, testCase.source)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError, testCase.source)
+				assert.ErrorIs(t, explanation.Err, testCase.expectedError, testCase.source)
+				continue
+			}
+			assert.NoError(t, err, testCase.source)
+			assert.Equal(t, testCase.expected, result, testCase.source)
+			assert.Equal(t, testCase.expected, explanation.Result, testCase.source)
+			reparsed, reparseError := ParseRuleNodeWithOptions(node.String(), options)
+			assert.NoError(t, reparseError, testCase.source)
+			assert.Equal(t, node, reparsed, testCase.source)
+		}
+	}
+}
+
+func TestOperatorCompileErrors(t *testing.T) {
+	operators := createTestOperators(t)
+	testCases := []struct {
+		name             string
+		source           string
+		expectedSentinel error
+		expectedOffset   int
+		expectedToken    string
+	}{
+		{name: "too many arguments", source: `CHECK(http.options.Email DISPOSABLE("x"))`, expectedSentinel: badArgsError, expectedOffset: 35, expectedToken: "("},
+		{name: "too few arguments", source: "CHECK(http.options.Email LONGER())", expectedSentinel: badArgsError, expectedOffset: 31, expectedToken: "("},
+		{name: "bad argument kind", source: `CHECK(http.options.Email LONGER("10"))`, expectedSentinel: badArgumentTypeError, expectedOffset: 31, expectedToken: "("},
+		{name: "bad variadic argument", source: `CHECK(http.options.Email DOMAIN_IN("a", 1))`, expectedSentinel: badArgumentTypeError, expectedOffset: 34, expectedToken: "("},
+		{name: "unknown operator", source: "CHECK(http.options.Email TEMPORARY())", expectedSentinel: unsupportedOperationError, expectedOffset: 25, expectedToken: "TEMPORARY"},
+		{name: "without list", source: "CHECK(http.options.Email DISPOSABLE)", expectedSentinel: parseError, expectedOffset: 35, expectedToken: ")"},
+	}
+	for _, testCase := range testCases {
+		_, actualError := ParseRuleWithOptions(testCase.source, CompileOptions{Operators: operators})
+		assert.ErrorIs(t, actualError, testCase.expectedSentinel, testCase.name)
+		var parseError *ParseError
+		if assert.True(t, errors.As(actualError, &parseError), testCase.name) {
+			assert.Equal(t, testCase.expectedOffset, parseError.Offset, testCase.name)
+			assert.Equal(t, testCase.expectedToken, parseError.Token, testCase.name)
+		}
+	}
+	var parseError *ParseError
+	_, unknownError := ParseRuleWithOptions("CHECK(http.options.Email TEMPORARY())", CompileOptions{Operators: operators})
+	assert.True(t, errors.As(unknownError, &parseError))
+	assert.Equal(t, append(append([]string{}, ruleOperationNames...), "DISPOSABLE", "DOMAIN_IN", "LONGER"), parseError.Expected)
+	// operators are validated when tree is compiled
+	node := CreateOperatorCheckNode(CreateDataPathWithSimpleContent(OptionsKey, "Email"), "DISPOSABLE")
+	_, withoutRegistryError := CompileWithOptions(node, CompileOptions{})
+	assert.ErrorIs(t, withoutRegistryError, unknownOperatorError)
+	_, unknownOperatorCompileError := CompileWithOptions(CreateOperatorCheckNode(node.Path, "TEMPORARY"), CompileOptions{Operators: operators})
+	assert.ErrorIs(t, unknownOperatorCompileError, unknownOperatorError)
+	_, bytecodeError := CompileWithOptions(CreateOperatorCheckNode(node.Path, "LONGER", "10"), CompileOptions{Operators: operators, Backend: BytecodeBackend})
+	assert.ErrorIs(t, bytecodeError, badArgumentTypeError)
+}
+
+func TestOperatorNodeJson(t *testing.T) {
+	node := CreateAndNode(
+		CreateOperatorCheckNode(CreateDataPathWithSimpleContent(OptionsKey, "Email"), "DOMAIN_IN", "example.com", "example.org"),
+		CreateOperatorCheckNode(CreateDataPathWithSimpleContent(OptionsKey, "Email"), "DISPOSABLE"),
+	)
+	encoded, encodeError := node.MarshalJSON()
+	assert.NoError(t, encodeError)
+	assert.Contains(t, string(encoded), `"operator":"DOMAIN_IN","argument":["example.com","example.org"]`)
+	assert.NotContains(t, string(encoded), `"operation"`)
+	decoded, decodeError := UnmarshalNode(encoded)
+	assert.NoError(t, decodeError)
+	assert.Equal(t, node, decoded)
+	_, bothError := UnmarshalNode([]byte(`{"kind": "check", "path": "http.options.A", "operation": "==", "operator": "DISPOSABLE", "argument": []}`))
+	assert.ErrorIs(t, bothError, badArgsError)
+	_, literalError := UnmarshalNode([]byte(`{"kind": "check", "path": "http.options.A", "operator": "LONGER", "argument": 1}`))
+	assert.ErrorIs(t, literalError, badArgumentTypeError)
+	omitted, omittedError := UnmarshalNode([]byte(`{"kind": "check", "path": "http.options.A", "operator": "DISPOSABLE"}`))
+	assert.NoError(t, omittedError)
+	assert.Equal(t, "CHECK(http.options.A DISPOSABLE())", omitted.String())
+}
@@ -0,0 +1,48 @@
+package expressiontree
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is error of expression parsing with position in source.
+// Err is one of parse sentinel errors (parseError, badArgsError, unknownExpressionError, etc.),
+// so ParseError can be checked via errors.Is.
+type ParseError struct {
+	// byte offset in source
+	Offset int
+	// line and column (in runes) are 1-based
+	Line   int
+	Column int
+	// offending token, empty at the end of source
+	Token    string
+	Expected []string
+	Err      error
+}
+
+func newParseError(source string, offset int, token string, expected []string, err error) *ParseError {
+	offset = min(max(offset, 0), len(source))
+	line := 1 + strings.Count(source[0:offset], "\n")
+	lineStart := strings.LastIndex(source[0:offset], "\n") + 1
+	column := 1 + utf8.RuneCountInString(source[lineStart:offset])
+	return &ParseError{Offset: offset, Line: line, Column: column, Token: token, Expected: expected, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "%v at line %d, column %d", e.Err, e.Line, e.Column)
+	if len(e.Token) == 0 {
+		builder.WriteString(": unexpected end of source")
+	} else {
+		fmt.Fprintf(builder, ": unexpected %q", e.Token)
+	}
+	if len(e.Expected) > 0 {
+		fmt.Fprintf(builder, ", expected %s", strings.Join(e.Expected, " or "))
+	}
+	return builder.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,138 @@
+package expressiontree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleParseErrorPosition(t *testing.T) {
+	testCases := []struct {
+		name             string
+		source           string
+		expectedSentinel error
+		expectedOffset   int
+		expectedLine     int
+		expectedColumn   int
+		expectedToken    string
+		expectedExpected []string
+	}{
+		{
+			name:             "unknown head",
+			source:           "AND(EXISTS(http.options.A),\n  XOR(EXISTS(http.options.B)))",
+			expectedSentinel: unknownExpressionError,
+			expectedOffset:   30,
+			expectedLine:     2,
+			expectedColumn:   3,
+			expectedToken:    "XOR",
+			expectedExpected: expressionHeads,
+		},
+		{
+			name:             "unclosed",
+			source:           "EXISTS(http.host",
+			expectedSentinel: parseError,
+			expectedOffset:   16,
+			expectedLine:     1,
+			expectedColumn:   17,
+			expectedToken:    "",
+			expectedExpected: []string{`")"`},
+		},
+		{
+			name:             "bad operation",
+			source:           "CHECK(http.port ~ 1)",
+			expectedSentinel: unsupportedOperationError,
+			expectedOffset:   16,
+			expectedLine:     1,
+			expectedColumn:   17,
+			expectedToken:    "~",
+			expectedExpected: ruleOperationNames,
+		},
+		{
+			name:             "bad path after unicode",
+			source:           "OR(CHECK(http.host == \"пример\"),\nEXISTS(web.absent))",
+			expectedSentinel: unknownMainPathError,
+			expectedOffset:   46,
+			expectedLine:     2,
+			expectedColumn:   8,
+			expectedToken:    "web.absent",
+			expectedExpected: []string{"path"},
+		},
+		{
+			name:             "column in runes",
+			source:           "CHECK(http.host == \"пример\" 1)",
+			expectedSentinel: parseError,
+			expectedOffset:   34,
+			expectedLine:     1,
+			expectedColumn:   29,
+			expectedToken:    "1",
+			expectedExpected: []string{`")"`},
+		},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			_, actualError := ParseRule(currentTestCase.source)
+			assert.ErrorIs(t, actualError, currentTestCase.expectedSentinel)
+			var parseError *ParseError
+			assert.True(t, errors.As(actualError, &parseError))
+			assert.Equal(t, currentTestCase.expectedOffset, parseError.Offset)
+			assert.Equal(t, currentTestCase.expectedLine, parseError.Line)
+			assert.Equal(t, currentTestCase.expectedColumn, parseError.Column)
+			assert.Equal(t, currentTestCase.expectedToken, parseError.Token)
+			assert.Equal(t, currentTestCase.expectedExpected, parseError.Expected)
+		})
+	}
+}
+
+func TestIndexedParseErrorPosition(t *testing.T) {
+	storage := &parseStorage{
+		knownPath:      []DataPath{CreateDataPathWithSimpleContent(OptionsKey, "IDDQD")},
+		checkArguments: []any{"IDDQD"},
+	}
+	testCases := []struct {
+		name             string
+		source           string
+		expectedSentinel error
+		expectedOffset   int
+		expectedToken    string
+	}{
+		{name: "unknown head", source: "AND(EXISTS(0),XOR(0))", expectedSentinel: unknownExpressionError, expectedOffset: 14, expectedToken: "XOR"},
+		{name: "bad integer", source: "AND(EXISTS(0),CHECK(0,x,0))", expectedSentinel: parseError, expectedOffset: 22, expectedToken: "x"},
+		{name: "arguments count", source: "MATCH(0)", expectedSentinel: badArgsError, expectedOffset: 6, expectedToken: "0"},
+		{name: "single argument", source: "OR(EXISTS(0))", expectedSentinel: badArgsError, expectedOffset: 12, expectedToken: ")"},
+		{name: "end of source", source: "NOT(EXISTS(0)", expectedSentinel: parseError, expectedOffset: 13, expectedToken: ""},
+		{name: "trailing data", source: "EXISTS(0)EXISTS(0)", expectedSentinel: parseError, expectedOffset: 9, expectedToken: "EXISTS(0)"},
+		{name: "path index", source: "AND(EXISTS(0),EXISTS(99))", expectedSentinel: indexOutOfRangeError, expectedOffset: 21, expectedToken: "99"},
+		{name: "negative path index", source: "CHECK(-1,0,0)", expectedSentinel: indexOutOfRangeError, expectedOffset: 6, expectedToken: "-1"},
+		{name: "argument index", source: "CHECK(0,0,1)", expectedSentinel: indexOutOfRangeError, expectedOffset: 10, expectedToken: "1"},
+		{name: "operation code", source: "CHECK(0,12,0)", expectedSentinel: unsupportedOperationError, expectedOffset: 8, expectedToken: "12"},
+		{name: "negative pattern id", source: "MATCH(0,-1)", expectedSentinel: badArgsError, expectedOffset: 8, expectedToken: "-1"},
+		{name: "empty arguments", source: "EXISTS()", expectedSentinel: parseError, expectedOffset: 7, expectedToken: ")"},
+		{name: "empty argument", source: "CHECK(0,,0)", expectedSentinel: parseError, expectedOffset: 8, expectedToken: ","},
+		{name: "empty logical argument", source: "OR(EXISTS(0),)", expectedSentinel: parseError, expectedOffset: 13, expectedToken: ")"},
+		{name: "empty NOT argument", source: "NOT()", expectedSentinel: parseError, expectedOffset: 4, expectedToken: ")"},
+		{name: "head after comments", source: "AND(/* é */ EXISTS(0), // ß\n XOR(0))", expectedSentinel: unknownExpressionError, expectedOffset: 31, expectedToken: "XOR"},
+		{name: "index after comment", source: "CHECK(0, 0, /* ü */ 5)", expectedSentinel: indexOutOfRangeError, expectedOffset: 21, expectedToken: "5"},
+		{name: "unterminated comment", source: "EXISTS(0) /* unclosed", expectedSentinel: parseError, expectedOffset: 10, expectedToken: "/* unclosed"},
+		{name: "invalid UTF-8", source: "EXISTS(0\xff)", expectedSentinel: parseError, expectedOffset: 8, expectedToken: "\xff"},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			_, actualError := parseExpressionTree(currentTestCase.source, storage)
+			assert.ErrorIs(t, actualError, currentTestCase.expectedSentinel)
+			var parseError *ParseError
+			assert.True(t, errors.As(actualError, &parseError))
+			assert.Equal(t, currentTestCase.expectedOffset, parseError.Offset)
+			assert.Equal(t, currentTestCase.expectedToken, parseError.Token)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, actualError := ParseRule("EXISTS(http.host")
+	assert.EqualError(t, actualError, `parse error at line 1, column 17: unexpected end of source, expected ")"`)
+	_, actualError = ParseRule("CHECK(http.port == 1) EXISTS(http.host)")
+	assert.EqualError(t, actualError, `parse error at line 1, column 23: unexpected "EXISTS", expected end of source`)
+}
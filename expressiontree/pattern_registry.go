@@ -0,0 +1,328 @@
+package expressiontree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// RegexPattern - value is regular expression (RE2 syntax)
+	RegexPattern = "regex"
+	// GlobPattern - value is glob: * - any sequence, ? - any symbol, [...] - class of symbols
+	GlobPattern = "glob"
+	// ExactPattern - value is equal to pattern
+	ExactPattern = "exact"
+	// SubstringPattern - value contains pattern, case is ignored always
+	SubstringPattern = "substring"
+	// LiteralSetPattern - value is equal to one of values
+	LiteralSetPattern = "set"
+	// MultiLiteralPattern - value contains one of values, all values are searched in one pass (Aho-Corasick)
+	MultiLiteralPattern = "multi"
+)
+
+var badPatternError = errors.New("bad pattern")
+var duplicatePatternError = errors.New("duplicate pattern")
+
+// PatternDefinition - if IgnoreCase is true, exact, set and multi patterns compare values converted by
+// strings.ToLower, regex and glob patterns use (?i) flag
+type PatternDefinition struct {
+	Id         uint     `json:"id" yaml:"id"`
+	Kind       string   `json:"kind" yaml:"kind"`
+	Value      string   `json:"value,omitempty" yaml:"value,omitempty"`
+	Values     []string `json:"values,omitempty" yaml:"values,omitempty"`
+	IgnoreCase bool     `json:"ignore_case,omitempty" yaml:"ignore_case,omitempty"`
+}
+
+type compiledPattern interface {
+	match(value string) bool
+}
+
+// PatternRegistry stores compiled patterns by id, it implements IPatternMatcher and ILiteralPatternMatcher
+// and is safe for concurrent use
+type PatternRegistry struct {
+	mutex    sync.RWMutex
+	patterns map[uint]compiledPattern
+}
+
+var _ IPatternMatcher = (*PatternRegistry)(nil)
+var _ ILiteralPatternMatcher = (*PatternRegistry)(nil)
+
+func CreatePatternRegistry() *PatternRegistry {
+	return &PatternRegistry{patterns: map[uint]compiledPattern{}}
+}
+
+// LoadPatternRegistry loads patterns from JSON file with array of PatternDefinition
+func LoadPatternRegistry(filename string) (*PatternRegistry, error) {
+	file, openError := os.Open(filename)
+	if openError != nil {
+		return nil, openError
+	}
+	defer file.Close()
+	registry := CreatePatternRegistry()
+	if loadError := registry.Load(file); loadError != nil {
+		return nil, loadError
+	}
+	return registry, nil
+}
+
+func (r *PatternRegistry) Load(reader io.Reader) error {
+	definitions := make([]PatternDefinition, 0)
+	if decodeError := json.NewDecoder(reader).Decode(&definitions); decodeError != nil {
+		return decodeError
+	}
+	for _, definition := range definitions {
+		if addError := r.Add(definition); addError != nil {
+			return addError
+		}
+	}
+	return nil
+}
+
+func (r *PatternRegistry) Add(definition PatternDefinition) error {
+	pattern, compileError := compilePattern(definition)
+	if compileError != nil {
+		return fmt.Errorf("pattern %d: %w", definition.Id, compileError)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.patterns[definition.Id]; exists {
+		return fmt.Errorf("pattern %d: %w", definition.Id, duplicatePatternError)
+	}
+	r.patterns[definition.Id] = pattern
+	return nil
+}
+
+func (r *PatternRegistry) Has(patternId uint) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	_, exists := r.patterns[patternId]
+	return exists
+}
+
+func (r *PatternRegistry) MatchPattern(patternId uint, value string) (bool, error) {
+	r.mutex.RLock()
+	pattern, exists := r.patterns[patternId]
+	r.mutex.RUnlock()
+	if !exists {
+		return false, unknownPatternError
+	}
+	return pattern.match(value), nil
+}
+
+// MatchPatternLiterals implements ILiteralPatternMatcher: literals of MultiLiteralPattern are found
+// by the same pass which matches value, literals are nil for other kinds of patterns
+func (r *PatternRegistry) MatchPatternLiterals(patternId uint, value string) (bool, []string, error) {
+	r.mutex.RLock()
+	pattern, exists := r.patterns[patternId]
+	r.mutex.RUnlock()
+	if !exists {
+		return false, nil, unknownPatternError
+	}
+	multiLiteral, isMultiLiteral := pattern.(*multiLiteralPattern)
+	if !isMultiLiteral {
+		return pattern.match(value), nil, nil
+	}
+	literals := multiLiteral.findAll(value)
+	return len(literals) > 0, literals, nil
+}
+
+// Clone returns registry with the same patterns, patterns added to clone aren't added to r
+func (r *PatternRegistry) Clone() *PatternRegistry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	clone := CreatePatternRegistry()
+	for patternId, pattern := range r.patterns {
+		clone.patterns[patternId] = pattern
+	}
+	return clone
+}
+
+// validatePattern is used at compile time, nil registry means that patterns aren't validated
+func (r *PatternRegistry) validatePattern(patternId uint) error {
+	if r == nil || r.Has(patternId) {
+		return nil
+	}
+	return unknownPatternError
+}
+
+func compilePattern(definition PatternDefinition) (compiledPattern, error) {
+	switch definition.Kind {
+	case RegexPattern:
+		source := definition.Value
+		if definition.IgnoreCase {
+			source = "(?i)" + source
+		}
+		expression, compileError := regexp.Compile(source)
+		if compileError != nil {
+			return nil, fmt.Errorf("%w: %w", badPatternError, compileError)
+		}
+		return &regexPattern{expression: expression}, nil
+	case GlobPattern:
+		expression, compileError := compileGlob(definition.Value, definition.IgnoreCase)
+		if compileError != nil {
+			return nil, compileError
+		}
+		return &regexPattern{expression: expression}, nil
+	case ExactPattern:
+		value := definition.Value
+		if definition.IgnoreCase {
+			value = strings.ToLower(value)
+		}
+		return &exactPattern{value: value, ignoreCase: definition.IgnoreCase}, nil
+	case SubstringPattern:
+		if len(definition.Value) == 0 {
+			return nil, badPatternError
+		}
+		return &substringPattern{value: strings.ToLower(definition.Value)}, nil
+	case LiteralSetPattern:
+		if len(definition.Values) == 0 {
+			return nil, badPatternError
+		}
+		values := make(map[string]struct{}, len(definition.Values))
+		for _, value := range definition.Values {
+			if definition.IgnoreCase {
+				value = strings.ToLower(value)
+			}
+			values[value] = struct{}{}
+		}
+		return &literalSetPattern{values: values, ignoreCase: definition.IgnoreCase}, nil
+	case MultiLiteralPattern:
+		if len(definition.Values) == 0 {
+			return nil, badPatternError
+		}
+		// duplicated literals are skipped, so every found literal is reported once
+		literals := make([]string, 0, len(definition.Values))
+		values := make([]string, 0, len(definition.Values))
+		seen := make(map[string]struct{}, len(definition.Values))
+		for _, value := range definition.Values {
+			literal := value
+			if definition.IgnoreCase {
+				literal = strings.ToLower(value)
+			}
+			if _, exists := seen[literal]; exists {
+				continue
+			}
+			seen[literal] = struct{}{}
+			literals = append(literals, literal)
+			values = append(values, value)
+		}
+		return &multiLiteralPattern{
+			automaton:  createAhoCorasick(literals),
+			values:     values,
+			ignoreCase: definition.IgnoreCase,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", badPatternError, definition.Kind)
+	}
+}
+
+func compileGlob(glob string, ignoreCase bool) (*regexp.Regexp, error) {
+	builder := &strings.Builder{}
+	if ignoreCase {
+		builder.WriteString("(?i)")
+	}
+	builder.WriteString("^")
+	runes := []rune(glob)
+	for index := 0; index < len(runes); index++ {
+		switch runes[index] {
+		case '*':
+			builder.WriteString("(?s:.*)")
+		case '?':
+			builder.WriteString("(?s:.)")
+		case '[':
+			end := index + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) || end == index+1 {
+				return nil, badPatternError
+			}
+			class := string(runes[index+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			builder.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			index = end
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(runes[index])))
+		}
+	}
+	builder.WriteString("$")
+	expression, compileError := regexp.Compile(builder.String())
+	if compileError != nil {
+		return nil, fmt.Errorf("%w: %w", badPatternError, compileError)
+	}
+	return expression, nil
+}
+
+type regexPattern struct {
+	expression *regexp.Regexp
+}
+
+func (p *regexPattern) match(value string) bool {
+	return p.expression.MatchString(value)
+}
+
+type exactPattern struct {
+	value      string
+	ignoreCase bool
+}
+
+func (p *exactPattern) match(value string) bool {
+	if p.ignoreCase {
+		value = strings.ToLower(value)
+	}
+	return p.value == value
+}
+
+type substringPattern struct {
+	value string
+}
+
+func (p *substringPattern) match(value string) bool {
+	return strings.Contains(strings.ToLower(value), p.value)
+}
+
+type literalSetPattern struct {
+	values     map[string]struct{}
+	ignoreCase bool
+}
+
+func (p *literalSetPattern) match(value string) bool {
+	if p.ignoreCase {
+		value = strings.ToLower(value)
+	}
+	_, exists := p.values[value]
+	return exists
+}
+
+type multiLiteralPattern struct {
+	automaton  *ahoCorasick
+	values     []string
+	ignoreCase bool
+}
+
+func (p *multiLiteralPattern) match(value string) bool {
+	if p.ignoreCase {
+		value = strings.ToLower(value)
+	}
+	return p.automaton.containsAny(value)
+}
+
+func (p *multiLiteralPattern) findAll(value string) []string {
+	if p.ignoreCase {
+		value = strings.ToLower(value)
+	}
+	indices := p.automaton.findAll(value)
+	found := make([]string, 0, len(indices))
+	for _, index := range indices {
+		found = append(found, p.values[index])
+	}
+	return found
+}
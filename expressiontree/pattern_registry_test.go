@@ -0,0 +1,123 @@
+package expressiontree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternRegistryMatch(t *testing.T) {
+	registry := CreatePatternRegistry()
+	definitions := []PatternDefinition{
+		{Id: 1, Kind: RegexPattern, Value: `^/api/v\d+/`},
+		{Id: 2, Kind: RegexPattern, Value: `^curl/`, IgnoreCase: true},
+		{Id: 3, Kind: GlobPattern, Value: "*.example.[cn]o?"},
+		{Id: 4, Kind: GlobPattern, Value: "/static/*.JS", IgnoreCase: true},
+		{Id: 5, Kind: ExactPattern, Value: "POST"},
+		{Id: 6, Kind: ExactPattern, Value: "post", IgnoreCase: true},
+		{Id: 7, Kind: SubstringPattern, Value: "SELECT"},
+		{Id: 8, Kind: LiteralSetPattern, Values: []string{"GET", "HEAD"}},
+		{Id: 9, Kind: LiteralSetPattern, Values: []string{"sqlmap", "nikto"}, IgnoreCase: true},
+		{Id: 10, Kind: GlobPattern, Value: "[!a-c]?"},
+		{Id: 11, Kind: ExactPattern, Value: "σ", IgnoreCase: true},
+		{Id: 12, Kind: LiteralSetPattern, Values: []string{"σ"}, IgnoreCase: true},
+	}
+	for _, definition := range definitions {
+		assert.NoError(t, registry.Add(definition))
+	}
+	testCases := []struct {
+		patternId      uint
+		value          string
+		expectedResult bool
+	}{
+		{patternId: 1, value: "/api/v2/users", expectedResult: true},
+		{patternId: 1, value: "/api/vx/users", expectedResult: false},
+		{patternId: 2, value: "CURL/8.0", expectedResult: true},
+		{patternId: 3, value: "www.example.com", expectedResult: true},
+		{patternId: 3, value: "www.example.net", expectedResult: false},
+		{patternId: 3, value: "example.com", expectedResult: false},
+		{patternId: 4, value: "/static/a/b.js", expectedResult: true},
+		{patternId: 5, value: "POST", expectedResult: true},
+		{patternId: 5, value: "post", expectedResult: false},
+		{patternId: 6, value: "Post", expectedResult: true},
+		{patternId: 7, value: "id=1 union select 1", expectedResult: true},
+		{patternId: 7, value: "id=1", expectedResult: false},
+		{patternId: 8, value: "HEAD", expectedResult: true},
+		{patternId: 8, value: "head", expectedResult: false},
+		{patternId: 9, value: "SQLMap", expectedResult: true},
+		{patternId: 10, value: "dx", expectedResult: true},
+		{patternId: 10, value: "ax", expectedResult: false},
+		// exact and set patterns fold case in the same way
+		{patternId: 11, value: "Σ", expectedResult: true},
+		{patternId: 11, value: "ς", expectedResult: false},
+		{patternId: 12, value: "Σ", expectedResult: true},
+		{patternId: 12, value: "ς", expectedResult: false},
+	}
+	for _, testCase := range testCases {
+		actualResult, actualError := registry.MatchPattern(testCase.patternId, testCase.value)
+		assert.NoError(t, actualError)
+		assert.Equal(t, testCase.expectedResult, actualResult, "pattern %d, value %q", testCase.patternId, testCase.value)
+	}
+	_, unknownError := registry.MatchPattern(666, "value")
+	assert.Equal(t, unknownPatternError, unknownError)
+}
+
+func TestPatternRegistryAddErrors(t *testing.T) {
+	registry := CreatePatternRegistry()
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 1, Kind: ExactPattern, Value: "a"}))
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 1, Kind: ExactPattern, Value: "b"}), duplicatePatternError)
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 2, Kind: RegexPattern, Value: "(a"}), badPatternError)
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 3, Kind: GlobPattern, Value: "a[b"}), badPatternError)
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 4, Kind: LiteralSetPattern}), badPatternError)
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 5, Kind: SubstringPattern}), badPatternError)
+	assert.ErrorIs(t, registry.Add(PatternDefinition{Id: 6, Kind: "fuzzy", Value: "a"}), badPatternError)
+}
+
+func TestLoadPatternRegistry(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "patterns.json")
+	content := `[
+		{"id": 1, "kind": "regex", "value": "bob"},
+		{"id": 2, "kind": "set", "values": ["nginx", "apache"]}
+	]`
+	assert.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+	registry, loadError := LoadPatternRegistry(filename)
+	assert.NoError(t, loadError)
+	assert.True(t, registry.Has(1))
+	assert.True(t, registry.Has(2))
+	assert.False(t, registry.Has(3))
+	_, badLoadError := LoadPatternRegistry(filepath.Join(t.TempDir(), "absent.json"))
+	assert.Error(t, badLoadError)
+	badRegistry := CreatePatternRegistry()
+	assert.ErrorIs(t, badRegistry.Load(strings.NewReader(`[{"id": 1, "kind": "regex", "value": "("}]`)), badPatternError)
+}
+
+func TestPatternValidationAtCompileTime(t *testing.T) {
+	registry := CreatePatternRegistry()
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 7, Kind: SubstringPattern, Value: "bob"}))
+	_, knownError := ParseRuleWithOptions("MATCH(http.request.body, 7)", CompileOptions{Patterns: registry})
+	assert.NoError(t, knownError)
+	_, unknownError := ParseRuleWithOptions("MATCH(http.request.body, 8)", CompileOptions{Patterns: registry})
+	assert.ErrorIs(t, unknownError, unknownPatternError)
+	storage := &parseStorage{
+		knownPath: []DataPath{CreateDataPathWithMainOnly(RequestBodyKey)},
+		patterns:  registry,
+	}
+	_, knownIndexedError := parseExpressionTree("MATCH(0,7)", storage)
+	assert.NoError(t, knownIndexedError)
+	_, unknownIndexedError := parseExpressionTree("MATCH(0,8)", storage)
+	assert.ErrorIs(t, unknownIndexedError, unknownPatternError)
+}
+
+func TestExecutionManagerWithPatternRegistry(t *testing.T) {
+	registry := CreatePatternRegistry()
+	assert.NoError(t, registry.Add(PatternDefinition{Id: 1, Kind: GlobPattern, Value: "Fire*"}))
+	expression, expressionError := ParseRuleWithOptions("MATCH(http.client.browser, 1)", CompileOptions{Patterns: registry})
+	assert.NoError(t, expressionError)
+	actualResult, actualError := expression(context.Background(), createSampleHttpData(), CreateExecutionManager(registry))
+	assert.NoError(t, actualError)
+	assert.True(t, actualResult)
+}
@@ -1,4 +1,4 @@
 package expressiontree
 
-// TODO (std_string) : think about type of value
-type Predicate func(value any) bool
+// Predicate checks value, error is returned if value can't be coerced to type of check argument
+type Predicate func(value any) (bool, error)
@@ -0,0 +1,381 @@
+package expressiontree
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coercion rules: value is converted to type of check argument.
+// Argument types: null, bool, number (all integer and float types are stored as float64), string.
+// null argument: value equals only to nil, ordering isn't supported.
+// bool argument: value must be bool or string parsed by strconv.ParseBool.
+// number argument: value must be integer/float (incl. json.Number), string with number,
+// time.Time (unix time in seconds) or time.Duration (seconds). NaN and infinities aren't numbers,
+// integers are compared exactly (also if they can't be represented by float64).
+// string argument: strings and []byte are used as is, numbers and bools are formatted,
+// time.Time is compared with argument as time if argument is RFC3339 time,
+// time.Duration is compared with argument as duration if argument is duration (e.g. "1.5s"),
+// otherwise they are formatted, fmt.Stringer is formatted via String().
+// nil value isn't equal to any non-null argument and can't be ordered.
+// If value can't be converted, predicate returns error (coercionError).
+// IN/NOT IN: argument is list of literals with the same type (null is allowed additionally).
+// BETWEEN: argument is list of two numbers or two strings (low and high bounds, both inclusive).
+// CONTAINS/STARTSWITH/ENDSWITH: argument must be string.
+
+var coercionError = errors.New("coercion error")
+var badArgumentTypeError = errors.New("bad argument type")
+
+type operandKind int
+
+const (
+	nullOperand operandKind = iota
+	boolOperand
+	numberOperand
+	stringOperand
+)
+
+// incomparable is result of compare if value or operand is NaN, equality and ordering are false for it
+const incomparable = 2
+
+// maxExactInteger is max integer which is represented by float64 exactly
+const maxExactInteger = 1 << 53
+
+// checkNumber is finite number, integer is set if number is integer which can't be represented by float64
+type checkNumber struct {
+	value   float64
+	integer *big.Int
+}
+
+type checkOperand struct {
+	kind          operandKind
+	boolValue     bool
+	numberValue   checkNumber
+	stringValue   string
+	hasTime       bool
+	timeValue     time.Time
+	hasDuration   bool
+	durationValue time.Duration
+}
+
+func newCheckOperand(argument any) (*checkOperand, error) {
+	if argument == nil {
+		return &checkOperand{kind: nullOperand}, nil
+	}
+	switch value := argument.(type) {
+	case bool:
+		return &checkOperand{kind: boolOperand, boolValue: value}, nil
+	case string:
+		operand := &checkOperand{kind: stringOperand, stringValue: value}
+		if timeValue, timeError := time.Parse(time.RFC3339Nano, value); timeError == nil {
+			operand.hasTime = true
+			operand.timeValue = timeValue
+		}
+		if durationValue, durationError := time.ParseDuration(value); durationError == nil {
+			operand.hasDuration = true
+			operand.durationValue = durationValue
+		}
+		return operand, nil
+	}
+	number, numberError := toCheckNumber(argument)
+	if numberError != nil {
+		return nil, badArgumentTypeError
+	}
+	return &checkOperand{kind: numberOperand, numberValue: number}, nil
+}
+
+func (o *checkOperand) isOrdered() bool {
+	return o.kind == numberOperand || o.kind == stringOperand
+}
+
+func (o *checkOperand) equal(value any) (bool, error) {
+	if o.kind == nullOperand || value == nil {
+		return o.kind == nullOperand && value == nil, nil
+	}
+	result, compareError := o.compare(value)
+	if compareError != nil {
+		return false, compareError
+	}
+	return result == 0, nil
+}
+
+// compare returns -1, 0, 1 if value is less than, equal to or greater than operand, or incomparable
+func (o *checkOperand) compare(value any) (int, error) {
+	if value == nil {
+		return 0, fmt.Errorf("%w: nil value can't be compared", coercionError)
+	}
+	switch o.kind {
+	case boolOperand:
+		boolValue, boolError := toBool(value)
+		if boolError != nil {
+			return 0, boolError
+		}
+		if boolValue == o.boolValue {
+			return 0, nil
+		}
+		return 1, nil
+	case numberOperand:
+		number, numberError := toCheckNumber(value)
+		if numberError != nil {
+			return 0, numberError
+		}
+		return compareNumbers(number, o.numberValue), nil
+	case stringOperand:
+		if timeValue, isTime := value.(time.Time); isTime && o.hasTime {
+			return timeValue.Compare(o.timeValue), nil
+		}
+		if durationValue, isDuration := value.(time.Duration); isDuration && o.hasDuration {
+			return cmp.Compare(durationValue, o.durationValue), nil
+		}
+		stringValue, stringError := toString(value)
+		if stringError != nil {
+			return 0, stringError
+		}
+		return strings.Compare(stringValue, o.stringValue), nil
+	default:
+		return 0, fmt.Errorf("%w: null argument can't be ordered", coercionError)
+	}
+}
+
+func compareNumbers(left checkNumber, right checkNumber) int {
+	switch {
+	case math.IsNaN(left.value) || math.IsNaN(right.value):
+		return incomparable
+	case left.integer != nil || right.integer != nil:
+		return left.exact().Cmp(right.exact())
+	default:
+		return cmp.Compare(left.value, right.value)
+	}
+}
+
+func (n checkNumber) exact() *big.Float {
+	if n.integer != nil {
+		return new(big.Float).SetInt(n.integer)
+	}
+	return big.NewFloat(n.value)
+}
+
+func toBool(value any) (bool, error) {
+	switch typedValue := value.(type) {
+	case bool:
+		return typedValue, nil
+	case string:
+		result, parseBoolError := strconv.ParseBool(typedValue)
+		if parseBoolError != nil {
+			return false, fmt.Errorf("%w: can't convert %q to bool", coercionError, typedValue)
+		}
+		return result, nil
+	default:
+		return false, fmt.Errorf("%w: can't convert %T to bool", coercionError, value)
+	}
+}
+
+func toNumber(value any) (float64, error) {
+	number, numberError := toCheckNumber(value)
+	return number.value, numberError
+}
+
+func toCheckNumber(value any) (checkNumber, error) {
+	switch typedValue := value.(type) {
+	case time.Time:
+		return checkNumber{value: float64(typedValue.UnixNano()) / float64(time.Second)}, nil
+	case time.Duration:
+		return checkNumber{value: typedValue.Seconds()}, nil
+	case json.Number:
+		return parseCheckNumber(typedValue.String())
+	case string:
+		return parseCheckNumber(strings.TrimSpace(typedValue))
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return createIntegerCheckNumber(reflectValue.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		integer := reflectValue.Uint()
+		if integer <= maxExactInteger {
+			return checkNumber{value: float64(integer)}, nil
+		}
+		return checkNumber{value: float64(integer), integer: new(big.Int).SetUint64(integer)}, nil
+	case reflect.Float32, reflect.Float64:
+		number := reflectValue.Float()
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return checkNumber{}, fmt.Errorf("%w: %v isn't finite number", coercionError, number)
+		}
+		return checkNumber{value: number}, nil
+	default:
+		return checkNumber{}, fmt.Errorf("%w: can't convert %T to number", coercionError, value)
+	}
+}
+
+func createIntegerCheckNumber(integer int64) checkNumber {
+	if integer >= -maxExactInteger && integer <= maxExactInteger {
+		return checkNumber{value: float64(integer)}
+	}
+	return checkNumber{value: float64(integer), integer: big.NewInt(integer)}
+}
+
+// parseCheckNumber parses decimal integer (of any size) or finite float
+func parseCheckNumber(source string) (checkNumber, error) {
+	if integer, parseIntError := strconv.ParseInt(source, 10, 64); parseIntError == nil {
+		return createIntegerCheckNumber(integer), nil
+	}
+	if integer, isInteger := new(big.Int).SetString(source, 10); isInteger {
+		number, _ := new(big.Float).SetInt(integer).Float64()
+		if !math.IsInf(number, 0) {
+			return checkNumber{value: number, integer: integer}, nil
+		}
+	}
+	number, parseFloatError := strconv.ParseFloat(source, 64)
+	if parseFloatError != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return checkNumber{}, fmt.Errorf("%w: can't convert %q to number", coercionError, source)
+	}
+	return checkNumber{value: number}, nil
+}
+
+func toString(value any) (string, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case []byte:
+		return string(typedValue), nil
+	case bool:
+		return strconv.FormatBool(typedValue), nil
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return typedValue.String(), nil
+	case json.Number:
+		return typedValue.String(), nil
+	case fmt.Stringer:
+		return typedValue.String(), nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(reflectValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(reflectValue.Float(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w: can't convert %T to string", coercionError, value)
+	}
+}
+
+func createEqualPredicate(argument any, negate bool) (Predicate, error) {
+	operand, operandError := newCheckOperand(argument)
+	if operandError != nil {
+		return nil, operandError
+	}
+	return func(value any) (bool, error) {
+		result, equalError := operand.equal(value)
+		if equalError != nil {
+			return false, equalError
+		}
+		return result != negate, nil
+	}, nil
+}
+
+func createOrderPredicate(argument any, accept func(compareResult int) bool) (Predicate, error) {
+	operand, operandError := newCheckOperand(argument)
+	if operandError != nil {
+		return nil, operandError
+	}
+	if !operand.isOrdered() {
+		return nil, badArgumentTypeError
+	}
+	return func(value any) (bool, error) {
+		compareResult, compareError := operand.compare(value)
+		if compareError != nil {
+			return false, compareError
+		}
+		return compareResult != incomparable && accept(compareResult), nil
+	}, nil
+}
+
+func createInPredicate(argument any, negate bool) (Predicate, error) {
+	items, isList := argument.([]any)
+	if !isList || len(items) == 0 {
+		return nil, badArgumentTypeError
+	}
+	operands := make([]*checkOperand, 0, len(items))
+	listKind := nullOperand
+	for _, item := range items {
+		operand, operandError := newCheckOperand(item)
+		if operandError != nil {
+			return nil, operandError
+		}
+		if operand.kind != nullOperand && listKind != nullOperand && operand.kind != listKind {
+			return nil, badArgumentTypeError
+		}
+		if operand.kind != nullOperand {
+			listKind = operand.kind
+		}
+		operands = append(operands, operand)
+	}
+	return func(value any) (bool, error) {
+		for _, operand := range operands {
+			result, equalError := operand.equal(value)
+			if equalError != nil {
+				return false, equalError
+			}
+			if result {
+				return !negate, nil
+			}
+		}
+		return negate, nil
+	}, nil
+}
+
+func createBetweenPredicate(argument any) (Predicate, error) {
+	bounds, isList := argument.([]any)
+	if !isList || len(bounds) != 2 {
+		return nil, badArgumentTypeError
+	}
+	low, lowError := newCheckOperand(bounds[0])
+	if lowError != nil {
+		return nil, lowError
+	}
+	high, highError := newCheckOperand(bounds[1])
+	if highError != nil {
+		return nil, highError
+	}
+	if !low.isOrdered() || low.kind != high.kind {
+		return nil, badArgumentTypeError
+	}
+	return func(value any) (bool, error) {
+		lowResult, lowCompareError := low.compare(value)
+		if lowCompareError != nil {
+			return false, lowCompareError
+		}
+		highResult, highCompareError := high.compare(value)
+		if highCompareError != nil {
+			return false, highCompareError
+		}
+		return lowResult != incomparable && highResult != incomparable && lowResult >= 0 && highResult <= 0, nil
+	}, nil
+}
+
+func createStringPredicate(argument any, check func(value string, argument string) bool) (Predicate, error) {
+	stringArgument, isString := argument.(string)
+	if !isString {
+		return nil, badArgumentTypeError
+	}
+	return func(value any) (bool, error) {
+		if value == nil {
+			return false, fmt.Errorf("%w: nil value can't be converted to string", coercionError)
+		}
+		stringValue, stringError := toString(value)
+		if stringError != nil {
+			return false, stringError
+		}
+		return check(stringValue, stringArgument), nil
+	}, nil
+}
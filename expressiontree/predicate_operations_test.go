@@ -0,0 +1,122 @@
+package expressiontree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredicateOperations(t *testing.T) {
+	timestamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		operation      int
+		argument       any
+		value          any
+		expectedResult bool
+		expectedError  error
+	}{
+		{operation: OperationEqual, argument: 200, value: float64(200), expectedResult: true},
+		{operation: OperationEqual, argument: 200.0, value: int(200), expectedResult: true},
+		{operation: OperationEqual, argument: 200, value: uint16(200), expectedResult: true},
+		{operation: OperationEqual, argument: 200, value: "200", expectedResult: true},
+		{operation: OperationEqual, argument: 200, value: json.Number("200"), expectedResult: true},
+		{operation: OperationEqual, argument: 200, value: "abc", expectedError: coercionError},
+		{operation: OperationEqual, argument: 200, value: true, expectedError: coercionError},
+		{operation: OperationEqual, argument: 200, value: nil, expectedResult: false},
+		{operation: OperationNotEqual, argument: 200, value: nil, expectedResult: true},
+		{operation: OperationEqual, argument: nil, value: nil, expectedResult: true},
+		{operation: OperationEqual, argument: nil, value: "", expectedResult: false},
+		{operation: OperationEqual, argument: "IDCLIP", value: "IDCLIP", expectedResult: true},
+		{operation: OperationEqual, argument: "IDCLIP", value: []byte("IDCLIP"), expectedResult: true},
+		{operation: OperationEqual, argument: "200", value: 200, expectedResult: true},
+		{operation: OperationEqual, argument: "10.0.0.1", value: net.ParseIP("10.0.0.1"), expectedResult: true},
+		{operation: OperationEqual, argument: "IDCLIP", value: struct{}{}, expectedError: coercionError},
+		{operation: OperationNotEqual, argument: "IDCLIP", value: "IDDQD", expectedResult: true},
+		{operation: OperationEqual, argument: true, value: "true", expectedResult: true},
+		{operation: OperationEqual, argument: true, value: false, expectedResult: false},
+		{operation: OperationEqual, argument: true, value: 1, expectedError: coercionError},
+		{operation: OperationGreater, argument: 499, value: 500, expectedResult: true},
+		{operation: OperationGreater, argument: 499, value: 499, expectedResult: false},
+		{operation: OperationGreaterOrEqual, argument: 1e6, value: int64(1000000), expectedResult: true},
+		{operation: OperationLess, argument: 1.5, value: 1500 * time.Millisecond, expectedResult: false},
+		{operation: OperationLess, argument: "1.6s", value: 1500 * time.Millisecond, expectedResult: true},
+		{operation: OperationLessOrEqual, argument: "b", value: "ab", expectedResult: true},
+		{operation: OperationLess, argument: 10, value: nil, expectedError: coercionError},
+		{operation: OperationGreater, argument: "2024-01-01T00:00:00Z", value: timestamp, expectedResult: true},
+		{operation: OperationGreater, argument: 1714564799, value: timestamp, expectedResult: true},
+		{operation: OperationIn, argument: []any{"GET", "HEAD"}, value: "HEAD", expectedResult: true},
+		{operation: OperationIn, argument: []any{"GET", "HEAD"}, value: "POST", expectedResult: false},
+		{operation: OperationIn, argument: []any{200, 204}, value: "x", expectedError: coercionError},
+		{operation: OperationNotIn, argument: []any{200, nil}, value: nil, expectedResult: false},
+		{operation: OperationNotIn, argument: []any{200, 204}, value: 404, expectedResult: true},
+		{operation: OperationContains, argument: "dmi", value: "/admin", expectedResult: true},
+		{operation: OperationContains, argument: "0", value: 404, expectedResult: true},
+		{operation: OperationContains, argument: "x", value: nil, expectedError: coercionError},
+		{operation: OperationStartsWith, argument: "/api", value: "/api/v1", expectedResult: true},
+		{operation: OperationEndsWith, argument: ".local", value: "host.local", expectedResult: true},
+		{operation: OperationEndsWith, argument: ".local", value: "host.com", expectedResult: false},
+		{operation: OperationBetween, argument: []any{100, 199}, value: 100, expectedResult: true},
+		{operation: OperationBetween, argument: []any{100, 199}, value: "199", expectedResult: true},
+		{operation: OperationBetween, argument: []any{100, 199}, value: 200, expectedResult: false},
+		{operation: OperationBetween, argument: []any{"a", "c"}, value: "b", expectedResult: true},
+		{operation: OperationEqual, argument: 5, value: "NaN", expectedError: coercionError},
+		{operation: OperationNotEqual, argument: 5, value: math.NaN(), expectedError: coercionError},
+		{operation: OperationBetween, argument: []any{1, 2}, value: "NaN", expectedError: coercionError},
+		{operation: OperationGreater, argument: 1, value: math.Inf(1), expectedError: coercionError},
+		{operation: OperationLess, argument: 1, value: "-Inf", expectedError: coercionError},
+		{operation: OperationIn, argument: []any{1, 2}, value: json.Number("Infinity"), expectedError: coercionError},
+		{operation: OperationEqual, argument: 9007199254740993, value: int64(9007199254740992), expectedResult: false},
+		{operation: OperationEqual, argument: 9007199254740993, value: "9007199254740993", expectedResult: true},
+		{operation: OperationEqual, argument: 9007199254740993, value: float64(9007199254740992), expectedResult: false},
+		{operation: OperationGreater, argument: 9007199254740992, value: uint64(9007199254740993), expectedResult: true},
+		{operation: OperationLess, argument: uint64(18446744073709551615), value: json.Number("18446744073709551614"), expectedResult: true},
+		{operation: OperationEqual, argument: uint64(18446744073709551615), value: "18446744073709551615", expectedResult: true},
+		{operation: OperationEqual, argument: uint64(18446744073709551615), value: 18446744073709551615.0, expectedResult: false},
+		{operation: OperationBetween, argument: []any{-9007199254740993, 0}, value: int64(-9007199254740994), expectedResult: false},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		name := fmt.Sprintf("%d(%v,%#v)", currentTestCase.operation, currentTestCase.argument, currentTestCase.value)
+		t.Run(name, func(t *testing.T) {
+			predicate, predicateError := parsePredicate(currentTestCase.operation, currentTestCase.argument)
+			assert.NoError(t, predicateError)
+			actualResult, actualError := predicate(currentTestCase.value)
+			assert.Equal(t, currentTestCase.expectedResult, actualResult)
+			assert.ErrorIs(t, actualError, currentTestCase.expectedError)
+		})
+	}
+}
+
+func TestParsePredicateBadArgument(t *testing.T) {
+	testCases := []struct {
+		operation int
+		argument  any
+	}{
+		{operation: OperationEqual, argument: struct{}{}},
+		{operation: OperationLess, argument: nil},
+		{operation: OperationGreater, argument: true},
+		{operation: OperationIn, argument: "GET"},
+		{operation: OperationIn, argument: []any{}},
+		{operation: OperationIn, argument: []any{"GET", 1}},
+		{operation: OperationContains, argument: 1},
+		{operation: OperationBetween, argument: []any{1}},
+		{operation: OperationBetween, argument: []any{1, "2"}},
+		{operation: OperationBetween, argument: []any{true, false}},
+		{operation: OperationEqual, argument: math.NaN()},
+		{operation: OperationLess, argument: math.Inf(1)},
+		{operation: OperationBetween, argument: []any{math.Inf(-1), 1}},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(fmt.Sprintf("%d(%v)", currentTestCase.operation, currentTestCase.argument), func(t *testing.T) {
+			predicate, predicateError := parsePredicate(currentTestCase.operation, currentTestCase.argument)
+			assert.Nil(t, predicate)
+			assert.Equal(t, badArgumentTypeError, predicateError)
+		})
+	}
+}
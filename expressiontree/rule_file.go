@@ -0,0 +1,179 @@
+package expressiontree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule file - declarative form of RuleSet (JSON or YAML):
+// paths:                      # known paths of indexed rules
+//   - http.request.method
+// arguments:                  # check arguments of indexed rules (string, number, bool, null or list of them)
+//   - POST
+// patterns:                   # PatternDefinition
+//   - {id: 1, kind: substring, value: bob}
+// rules:
+//   - id: post
+//     priority: 1
+//     tags: [method]
+//     syntax: indexed         # "rule" (default) or "indexed"
+//     expression: CHECK(0,0,0)
+
+const (
+	RuleLanguageSyntaxName = "rule"
+	IndexedSyntaxName      = "indexed"
+)
+
+// RuleFileFormat is format of rule file
+type RuleFileFormat int
+
+const (
+	JsonRuleFile RuleFileFormat = iota
+	YamlRuleFile
+)
+
+var unknownRuleFileFormatError = errors.New("unknown rule file format")
+
+type RuleFile struct {
+	Paths     []string            `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Arguments []any               `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+	Patterns  []PatternDefinition `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Rules     []RuleFileRule      `json:"rules" yaml:"rules"`
+}
+
+type RuleFileRule struct {
+	Id         string   `json:"id" yaml:"id"`
+	Priority   int      `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Tags       []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Syntax     string   `json:"syntax,omitempty" yaml:"syntax,omitempty"`
+	Expression string   `json:"expression" yaml:"expression"`
+}
+
+// RuleFileError is error of rule file field
+type RuleFileError struct {
+	// id of rule (or its index if id is empty), empty for fields of file
+	Rule string
+	// name of field, e.g. expression or paths[2]
+	Field string
+	Err   error
+}
+
+func (e *RuleFileError) Error() string {
+	if e.Rule == "" {
+		return fmt.Sprintf("field %s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("rule %s, field %s: %v", e.Rule, e.Field, e.Err)
+}
+
+func (e *RuleFileError) Unwrap() error {
+	return e.Err
+}
+
+// LoadRuleFile loads rule set from JSON (.json) or YAML (.yaml, .yml) file
+func LoadRuleFile(filename string, options CompileOptions) (*RuleSet, error) {
+	var format RuleFileFormat
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		format = JsonRuleFile
+	case ".yaml", ".yml":
+		format = YamlRuleFile
+	default:
+		return nil, fmt.Errorf("%s: %w", filename, unknownRuleFileFormatError)
+	}
+	file, openError := os.Open(filename)
+	if openError != nil {
+		return nil, openError
+	}
+	defer file.Close()
+	return ReadRuleFile(file, format, options)
+}
+
+// ReadRuleFile reads rule file, unknown fields are errors
+func ReadRuleFile(reader io.Reader, format RuleFileFormat, options CompileOptions) (*RuleSet, error) {
+	var ruleFile RuleFile
+	switch format {
+	case JsonRuleFile:
+		decoder := json.NewDecoder(reader)
+		decoder.UseNumber()
+		decoder.DisallowUnknownFields()
+		if decodeError := decoder.Decode(&ruleFile); decodeError != nil {
+			return nil, decodeError
+		}
+	case YamlRuleFile:
+		decoder := yaml.NewDecoder(reader)
+		decoder.KnownFields(true)
+		if decodeError := decoder.Decode(&ruleFile); decodeError != nil && decodeError != io.EOF {
+			return nil, decodeError
+		}
+	default:
+		return nil, unknownRuleFileFormatError
+	}
+	return CreateRuleSetFromFile(&ruleFile, options)
+}
+
+// CreateRuleSetFromFile validates and compiles rule file. Patterns of file are added to clone of options.Patterns
+// (new registry is created if it is nil), so options.Patterns isn't changed. Rules with invalid pattern ids
+// aren't compiled.
+func CreateRuleSetFromFile(ruleFile *RuleFile, options CompileOptions) (*RuleSet, error) {
+	paths := make([]DataPath, 0, len(ruleFile.Paths))
+	for index, source := range ruleFile.Paths {
+		path, pathError := ParseDataPath(source)
+		if pathError != nil {
+			return nil, &RuleFileError{Field: fmt.Sprintf("paths[%d]", index), Err: pathError}
+		}
+		paths = append(paths, path)
+	}
+	arguments := make([]any, 0, len(ruleFile.Arguments))
+	for index, source := range ruleFile.Arguments {
+		argument, argumentError := normalizeJsonLiteral(source)
+		if argumentError != nil {
+			return nil, &RuleFileError{Field: fmt.Sprintf("arguments[%d]", index), Err: argumentError}
+		}
+		arguments = append(arguments, argument)
+	}
+	if options.Patterns == nil {
+		options.Patterns = CreatePatternRegistry()
+	} else {
+		options.Patterns = options.Patterns.Clone()
+	}
+	for index, definition := range ruleFile.Patterns {
+		if patternError := options.Patterns.Add(definition); patternError != nil {
+			return nil, &RuleFileError{Field: fmt.Sprintf("patterns[%d]", index), Err: patternError}
+		}
+	}
+	ruleSet := CreateRuleSet(paths, arguments, options)
+	for index, rule := range ruleFile.Rules {
+		ruleName := strconv.Quote(rule.Id)
+		if rule.Id == "" {
+			ruleName = fmt.Sprintf("rules[%d]", index)
+		}
+		syntax, syntaxError := parseRuleSyntax(rule.Syntax)
+		if syntaxError != nil {
+			return nil, &RuleFileError{Rule: ruleName, Field: "syntax", Err: syntaxError}
+		}
+		definition := RuleDefinition{Id: rule.Id, Priority: rule.Priority, Tags: rule.Tags, Syntax: syntax, Source: rule.Expression}
+		if field, addError := ruleSet.add(definition); addError != nil {
+			return nil, &RuleFileError{Rule: ruleName, Field: field, Err: addError}
+		}
+	}
+	return ruleSet, nil
+}
+
+func parseRuleSyntax(name string) (RuleSyntax, error) {
+	switch name {
+	case "", RuleLanguageSyntaxName:
+		return RuleLanguageSyntax, nil
+	case IndexedSyntaxName:
+		return IndexedSyntax, nil
+	default:
+		return 0, fmt.Errorf("%q: %w", name, unknownRuleSyntaxError)
+	}
+}
@@ -0,0 +1,176 @@
+package expressiontree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const yamlRuleFile = `
+paths:
+  - http.request.method
+  - http.options.IDDQD
+arguments:
+  - POST
+  - [80, 443]
+patterns:
+  - {id: 1, kind: substring, value: bob}
+rules:
+  - id: post
+    priority: 1
+    tags: [method]
+    syntax: indexed
+    expression: CHECK(0,0,0)
+  - id: god
+    priority: 10
+    syntax: indexed
+    expression: EXISTS(1)
+  - id: bob
+    expression: MATCH(http.request.body, 1)
+  - id: port
+    expression: CHECK(http.port IN (80, 443))
+`
+
+const jsonRuleFile = `{
+  "paths": ["http.request.method", "http.options.IDDQD"],
+  "arguments": ["POST", [80, 443]],
+  "patterns": [{"id": 1, "kind": "substring", "value": "bob"}],
+  "rules": [
+    {"id": "post", "priority": 1, "tags": ["method"], "syntax": "indexed", "expression": "CHECK(0,0,0)"},
+    {"id": "god", "priority": 10, "syntax": "indexed", "expression": "EXISTS(1)"},
+    {"id": "bob", "expression": "MATCH(http.request.body, 1)"},
+    {"id": "port", "expression": "CHECK(http.port IN (80, 443))"}
+  ]
+}`
+
+func TestLoadRuleFile(t *testing.T) {
+	for name, content := range map[string]string{"rules.yaml": yamlRuleFile, "rules.json": jsonRuleFile} {
+		filename := filepath.Join(t.TempDir(), name)
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+		ruleSet, loadError := LoadRuleFile(filename, CompileOptions{})
+		if !assert.NoError(t, loadError, name) {
+			continue
+		}
+		matched, evaluateError := ruleSet.Evaluate(context.Background(), createSampleHttpData(), CreateExecutionManager(ruleSet.Patterns()))
+		assert.NoError(t, evaluateError, name)
+		matchedIds := []string{}
+		for _, rule := range matched {
+			matchedIds = append(matchedIds, rule.Id)
+		}
+		assert.Equal(t, []string{"god", "post", "bob", "port"}, matchedIds, name)
+		assert.Equal(t, []string{"method"}, matched[1].Tags, name)
+		assert.Equal(t, `CHECK(http.request.method == "POST")`, matched[1].Node.String(), name)
+	}
+	_, unknownFormatError := LoadRuleFile(filepath.Join(t.TempDir(), "rules.txt"), CompileOptions{})
+	assert.ErrorIs(t, unknownFormatError, unknownRuleFileFormatError)
+	_, absentError := LoadRuleFile(filepath.Join(t.TempDir(), "absent.json"), CompileOptions{})
+	assert.ErrorIs(t, absentError, os.ErrNotExist)
+}
+
+func TestReadRuleFileError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		expectedError error
+		expectedText  string
+	}{
+		{
+			name:          "bad path",
+			source:        "paths: [http.request.method, unknown]\nrules: []",
+			expectedError: unknownMainPathError,
+			expectedText:  "field paths[1]: unknown main path",
+		},
+		{
+			name:          "bad argument",
+			source:        "arguments: [1, {a: 1}]\nrules: []",
+			expectedError: badLiteralError,
+			expectedText:  "field arguments[1]: bad literal",
+		},
+		{
+			name:          "nan argument",
+			source:        "arguments: [1, .nan]\nrules: []",
+			expectedError: badLiteralError,
+			expectedText:  "field arguments[1]: bad literal",
+		},
+		{
+			name:          "infinite argument",
+			source:        "arguments: [[1, -.inf]]\nrules: []",
+			expectedError: badLiteralError,
+			expectedText:  "field arguments[0]: bad literal",
+		},
+		{
+			name:          "bad pattern",
+			source:        "patterns: [{id: 1, kind: regex, value: '('}]\nrules: []",
+			expectedError: badPatternError,
+		},
+		{
+			name:          "path index",
+			source:        "paths: [http.options.A]\nrules: [{id: a, syntax: indexed, expression: 'EXISTS(1)'}]",
+			expectedError: indexOutOfRangeError,
+			expectedText:  `rule "a", field expression: index out of range at line 1, column 8: unexpected "1", expected path index less than 1`,
+		},
+		{
+			name: "argument index",
+			source: "paths: [http.request.method]\narguments: [GET]\n" +
+				"rules: [{id: a, syntax: indexed, expression: 'CHECK(0,0,-1)'}]",
+			expectedError: indexOutOfRangeError,
+			expectedText:  `rule "a", field expression: index out of range at line 1, column 11: unexpected "-1", expected argument index less than 1`,
+		},
+		{
+			name:          "unknown syntax",
+			source:        "rules: [{id: a, syntax: lisp, expression: 'TRUE()'}]",
+			expectedError: unknownRuleSyntaxError,
+			expectedText:  `rule "a", field syntax: "lisp": unknown rule syntax`,
+		},
+		{
+			name:          "empty id",
+			source:        "rules: [{id: a, expression: 'TRUE()'}, {expression: 'TRUE()'}]",
+			expectedError: emptyRuleIdError,
+			expectedText:  "rule rules[1], field id: empty rule id",
+		},
+		{
+			name:          "duplicate id",
+			source:        "rules: [{id: a, expression: 'TRUE()'}, {id: a, expression: 'TRUE()'}]",
+			expectedError: duplicateRuleError,
+			expectedText:  `rule "a", field id: duplicate rule`,
+		},
+		{
+			name:          "unknown pattern",
+			source:        "rules: [{id: a, expression: 'MATCH(http.host, 3)'}]",
+			expectedError: unknownPatternError,
+		},
+	}
+	for _, testCase := range testCases {
+		_, readError := ReadRuleFile(strings.NewReader(testCase.source), YamlRuleFile, CompileOptions{})
+		assert.ErrorIs(t, readError, testCase.expectedError, testCase.name)
+		if testCase.expectedText != "" {
+			assert.EqualError(t, readError, testCase.expectedText, testCase.name)
+		}
+	}
+	_, unknownFieldError := ReadRuleFile(strings.NewReader(`{"rules": [], "extra": 1}`), JsonRuleFile, CompileOptions{})
+	assert.Error(t, unknownFieldError)
+	_, unknownYamlFieldError := ReadRuleFile(strings.NewReader("rules: []\nextra: 1"), YamlRuleFile, CompileOptions{})
+	assert.Error(t, unknownYamlFieldError)
+}
+
+func TestReadRuleFileKeepsPatterns(t *testing.T) {
+	patterns := CreatePatternRegistry()
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 2, Kind: ExactPattern, Value: "GET"}))
+	ruleSet, readError := ReadRuleFile(strings.NewReader(yamlRuleFile), YamlRuleFile, CompileOptions{Patterns: patterns})
+	assert.NoError(t, readError)
+	assert.True(t, ruleSet.Patterns().Has(1))
+	assert.True(t, ruleSet.Patterns().Has(2))
+	assert.False(t, patterns.Has(1))
+	// failed file doesn't add its patterns too
+	source := "patterns: [{id: 3, kind: exact, value: a}]\nrules: [{id: a, expression: 'MATCH(http.host, 4)'}]"
+	_, readError = ReadRuleFile(strings.NewReader(source), YamlRuleFile, CompileOptions{Patterns: patterns})
+	assert.ErrorIs(t, readError, unknownPatternError)
+	assert.False(t, patterns.Has(3))
+	// the same file can be read twice with the same registry
+	_, readError = ReadRuleFile(strings.NewReader(yamlRuleFile), YamlRuleFile, CompileOptions{Patterns: patterns})
+	assert.NoError(t, readError)
+}
@@ -0,0 +1,191 @@
+package expressiontree
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenOperator
+)
+
+var tokenKindNames = map[tokenKind]string{
+	tokenEOF:        "end of source",
+	tokenWord:       "word",
+	tokenString:     "string",
+	tokenLeftParen:  `"("`,
+	tokenRightParen: `")"`,
+	tokenComma:      `","`,
+	tokenOperator:   "operator",
+}
+
+func (kind tokenKind) String() string {
+	return tokenKindNames[kind]
+}
+
+// word is any run of symbols except whitespace, delimiters, quotes and operator symbols:
+// keywords, data paths, numbers, true/false/null are words
+// quoted name after dot is part of word (quoted content part of data path)
+const ruleDelimiters = `(),"=!<>`
+
+// comments are allowed between tokens: line comment "// ..." and block comment "/* ... */"
+const (
+	lineCommentStart  = "//"
+	blockCommentStart = "/*"
+	blockCommentEnd   = "*/"
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+type ruleLexer struct {
+	source string
+	offset int
+}
+
+func newRuleLexer(source string) *ruleLexer {
+	return &ruleLexer{source: source, offset: 0}
+}
+
+func (l *ruleLexer) next() (token, error) {
+	if skipError := l.skipSpaces(); skipError != nil {
+		return token{}, skipError
+	}
+	start := l.offset
+	if l.offset >= len(l.source) {
+		return token{kind: tokenEOF, text: "", offset: start}, nil
+	}
+	char, size := utf8.DecodeRuneInString(l.source[l.offset:])
+	if char == utf8.RuneError && size == 1 {
+		return token{}, l.invalidSymbolError()
+	}
+	switch char {
+	case '(':
+		l.offset += size
+		return token{kind: tokenLeftParen, text: "(", offset: start}, nil
+	case ')':
+		l.offset += size
+		return token{kind: tokenRightParen, text: ")", offset: start}, nil
+	case ',':
+		l.offset += size
+		return token{kind: tokenComma, text: ",", offset: start}, nil
+	case '"':
+		return l.readString()
+	case '=', '!', '<', '>':
+		return l.readOperator()
+	default:
+		return l.readWord()
+	}
+}
+
+// skipSpaces skips whitespace and comments, returns error for unterminated block comment
+func (l *ruleLexer) skipSpaces() error {
+	for l.offset < len(l.source) {
+		rest := l.source[l.offset:]
+		switch {
+		case strings.HasPrefix(rest, lineCommentStart):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			l.offset += end
+		case strings.HasPrefix(rest, blockCommentStart):
+			end := strings.Index(rest[len(blockCommentStart):], blockCommentEnd)
+			if end < 0 {
+				return newParseError(l.source, l.offset, rest, []string{blockCommentEnd}, parseError)
+			}
+			l.offset += len(blockCommentStart) + end + len(blockCommentEnd)
+		default:
+			char, size := utf8.DecodeRuneInString(rest)
+			if !unicode.IsSpace(char) {
+				return nil
+			}
+			l.offset += size
+		}
+	}
+	return nil
+}
+
+func (l *ruleLexer) isCommentStart() bool {
+	rest := l.source[l.offset:]
+	return strings.HasPrefix(rest, lineCommentStart) || strings.HasPrefix(rest, blockCommentStart)
+}
+
+// invalidSymbolError is error for byte at current offset which isn't start of UTF-8 encoded symbol
+func (l *ruleLexer) invalidSymbolError() error {
+	return newParseError(l.source, l.offset, l.source[l.offset:l.offset+1], []string{"UTF-8 symbol"}, parseError)
+}
+
+func (l *ruleLexer) readWord() (token, error) {
+	start := l.offset
+	for l.offset < len(l.source) {
+		char, size := utf8.DecodeRuneInString(l.source[l.offset:])
+		if char == '"' && l.offset > start && l.source[l.offset-1] == '.' {
+			if _, stringError := l.readString(); stringError != nil {
+				return token{}, stringError
+			}
+			continue
+		}
+		if char == utf8.RuneError && size == 1 {
+			return token{}, l.invalidSymbolError()
+		}
+		if unicode.IsSpace(char) || strings.ContainsRune(ruleDelimiters, char) || l.isCommentStart() {
+			break
+		}
+		l.offset += size
+	}
+	return token{kind: tokenWord, text: l.source[start:l.offset], offset: start}, nil
+}
+
+func (l *ruleLexer) readString() (token, error) {
+	start := l.offset
+	// skip opening quote
+	l.offset++
+	for l.offset < len(l.source) {
+		switch l.source[l.offset] {
+		case '\\':
+			l.offset += 2
+		case '"':
+			l.offset++
+			return token{kind: tokenString, text: l.source[start:l.offset], offset: start}, nil
+		default:
+			l.offset++
+		}
+	}
+	return token{}, newParseError(l.source, start, l.source[start:], []string{`"`}, parseError)
+}
+
+func (l *ruleLexer) readOperator() (token, error) {
+	start := l.offset
+	first := l.source[l.offset]
+	l.offset++
+	hasEqual := l.offset < len(l.source) && l.source[l.offset] == '='
+	if hasEqual {
+		l.offset++
+	}
+	if (first == '=' || first == '!') && !hasEqual {
+		return token{}, newParseError(l.source, start, l.source[start:l.offset], []string{"==", "!="}, parseError)
+	}
+	return token{kind: tokenOperator, text: l.source[start:l.offset], offset: start}, nil
+}
+
+func (t *token) stringValue() (string, error) {
+	value, unquoteError := strconv.Unquote(t.text)
+	if unquoteError != nil {
+		return "", badLiteralError
+	}
+	return value, nil
+}
@@ -0,0 +1,434 @@
+package expressiontree
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Rule language - human-readable form of expression tree:
+// AND(COND1,COND2,...)
+// OR(COND1,COND2,...)
+// NOT(COND)
+// TRUE()
+// FALSE()
+// COND: CHECK(PATH OP LITERAL) | CHECK(PATH LIST_OP (LITERAL,...)) | CHECK(PATH BETWEEN LITERAL AND LITERAL) |
+//       CHECK(PATH OPERATOR(LITERAL,...)) | EXISTS(PATH) | MATCH(PATH,PATTERN)
+// PATH: dotted path (see ParseDataPath), e.g. http.request.headers.X-Token
+// OP: == | != | < | <= | > | >= | CONTAINS | STARTSWITH | ENDSWITH
+// LIST_OP: IN | NOT IN
+// OPERATOR: name of custom operator (see CompileOptions.Operators), list of its literals may be empty
+// LITERAL: "string" | number | true | false | null
+// PATTERN: INT
+// e.g. AND(EXISTS(http.options.IDDQD), CHECK(http.request.headers.X-Token == "abc"))
+// All parse errors are *ParseError with position of offending token
+
+var badLiteralError = errors.New("bad literal")
+
+var expressionHeads = []string{"AND", "OR", "NOT", "CHECK", "EXISTS", "MATCH", "TRUE", "FALSE"}
+var ruleOperationNames = []string{"==", "!=", "<", "<=", ">", ">=", "IN", "NOT IN", "CONTAINS", "STARTSWITH", "ENDSWITH", "BETWEEN"}
+var literalNames = []string{"string", "number", "true", "false", "null"}
+
+type CompileOptions struct {
+	// if not nil, pattern ids of MATCH are validated at compile time
+	Patterns *PatternRegistry
+	// if not nil, results of leaves are recorded for cost model (see Reorder)
+	Stats *SelectivityStats
+	// errors handling of logical operators, StrictErrors by default
+	Mode EvaluationMode
+	// if positive, each evaluation of compiled predicate has deadline (in addition to deadline of ctx)
+	Timeout time.Duration
+	// form of compiled predicate, ClosureBackend by default
+	Backend CompileBackend
+	// if not nil, expensive operands of AND/OR are evaluated concurrently (it isn't supported by BytecodeBackend)
+	Parallel *ParallelEvaluator
+	// if not nil, manager calls of leaves (and rules of RuleSet) are recorded in metrics
+	Metrics *EvaluationMetrics
+	// custom operators of CHECK, unknown operators and bad arguments are compile errors
+	Operators *OperatorRegistry
+}
+
+type ruleParser struct {
+	lexer   *ruleLexer
+	current token
+	options CompileOptions
+}
+
+func newRuleParser(source string, options CompileOptions) (*ruleParser, error) {
+	parser := &ruleParser{lexer: newRuleLexer(source), options: options}
+	if advanceError := parser.advance(); advanceError != nil {
+		return nil, advanceError
+	}
+	return parser, nil
+}
+
+func (p *ruleParser) advance() error {
+	current, nextError := p.lexer.next()
+	if nextError != nil {
+		return nextError
+	}
+	p.current = current
+	return nil
+}
+
+// expect checks kind of current token and advances, expected describes token in error (kind name by default)
+func (p *ruleParser) expect(kind tokenKind, expected ...string) (token, error) {
+	current := p.current
+	if current.kind != kind {
+		if len(expected) == 0 {
+			expected = []string{kind.String()}
+		}
+		return token{}, p.errorAt(current, expected, parseError)
+	}
+	if advanceError := p.advance(); advanceError != nil {
+		return token{}, advanceError
+	}
+	return current, nil
+}
+
+func (p *ruleParser) errorAt(place token, expected []string, err error) error {
+	return newParseError(p.lexer.source, place.offset, place.text, expected, err)
+}
+
+// ParseRule parses expression written in rule language and compiles it into predicate
+func ParseRule(source string) (PredicateWithError, error) {
+	return ParseRuleWithOptions(source, CompileOptions{})
+}
+
+func ParseRuleWithOptions(source string, options CompileOptions) (PredicateWithError, error) {
+	node, nodeError := ParseRuleNodeWithOptions(source, options)
+	if nodeError != nil {
+		return nil, nodeError
+	}
+	return CompileWithOptions(node, options)
+}
+
+// ParseRuleNode parses expression written in rule language into expression tree
+func ParseRuleNode(source string) (Node, error) {
+	return ParseRuleNodeWithOptions(source, CompileOptions{})
+}
+
+// ParseRuleNodeWithOptions parses expression into expression tree, leaves are validated as in CompileWithOptions,
+// so returned tree can be compiled without errors
+func ParseRuleNodeWithOptions(source string, options CompileOptions) (Node, error) {
+	parser, parserError := newRuleParser(source, options)
+	if parserError != nil {
+		return nil, parserError
+	}
+	node, nodeError := parser.parseExpression()
+	if nodeError != nil {
+		return nil, nodeError
+	}
+	if parser.current.kind != tokenEOF {
+		return nil, parser.errorAt(parser.current, []string{tokenEOF.String()}, parseError)
+	}
+	return node, nil
+}
+
+func (p *ruleParser) parseExpression() (Node, error) {
+	head, headError := p.expect(tokenWord, expressionHeads...)
+	if headError != nil {
+		return nil, headError
+	}
+	if _, openError := p.expect(tokenLeftParen); openError != nil {
+		return nil, openError
+	}
+	switch head.text {
+	case "AND":
+		arguments, argumentsError := p.parseLogicalExpressionArgs()
+		if argumentsError != nil {
+			return nil, argumentsError
+		}
+		return CreateAndNode(arguments...), nil
+	case "OR":
+		arguments, argumentsError := p.parseLogicalExpressionArgs()
+		if argumentsError != nil {
+			return nil, argumentsError
+		}
+		return CreateOrNode(arguments...), nil
+	case "NOT":
+		innerExpression, innerExpressionErr := p.parseExpression()
+		if innerExpressionErr != nil {
+			return nil, innerExpressionErr
+		}
+		if _, closeError := p.expect(tokenRightParen); closeError != nil {
+			return nil, closeError
+		}
+		return CreateNotNode(innerExpression), nil
+	case "CHECK":
+		return p.parseCheck()
+	case "EXISTS":
+		return p.parseExists()
+	case "MATCH":
+		return p.parseMatch()
+	case "TRUE", "FALSE":
+		if _, closeError := p.expect(tokenRightParen); closeError != nil {
+			return nil, closeError
+		}
+		return CreateConstNode(head.text == "TRUE"), nil
+	default:
+		return nil, p.errorAt(head, expressionHeads, unknownExpressionError)
+	}
+}
+
+func (p *ruleParser) parseLogicalExpressionArgs() ([]Node, error) {
+	arguments := make([]Node, 0)
+	for {
+		argument, argumentError := p.parseExpression()
+		if argumentError != nil {
+			return nil, argumentError
+		}
+		arguments = append(arguments, argument)
+		separator, separatorError := p.expectOneOf(tokenComma, tokenRightParen)
+		if separatorError != nil {
+			return nil, separatorError
+		}
+		if separator.kind == tokenRightParen {
+			if len(arguments) <= 1 {
+				return nil, p.errorAt(separator, []string{tokenComma.String()}, badArgsError)
+			}
+			return arguments, nil
+		}
+	}
+}
+
+func (p *ruleParser) parseExists() (Node, error) {
+	path, pathToken, pathError := p.parsePath()
+	if pathError != nil {
+		return nil, pathError
+	}
+	if _, closeError := p.expect(tokenRightParen); closeError != nil {
+		return nil, closeError
+	}
+	if _, expressionError := createExists(path); expressionError != nil {
+		return nil, p.errorAt(pathToken, nil, expressionError)
+	}
+	return CreateExistsNode(path), nil
+}
+
+func (p *ruleParser) parseMatch() (Node, error) {
+	path, pathToken, pathError := p.parsePath()
+	if pathError != nil {
+		return nil, pathError
+	}
+	if _, commaError := p.expect(tokenComma); commaError != nil {
+		return nil, commaError
+	}
+	patternToken, patternError := p.expect(tokenWord, "pattern id")
+	if patternError != nil {
+		return nil, patternError
+	}
+	patternId, convertError := strconv.ParseUint(patternToken.text, 10, 0)
+	if convertError != nil {
+		return nil, p.errorAt(patternToken, []string{"pattern id"}, badArgsError)
+	}
+	if patternError := p.options.Patterns.validatePattern(uint(patternId)); patternError != nil {
+		return nil, p.errorAt(patternToken, nil, patternError)
+	}
+	if _, closeError := p.expect(tokenRightParen); closeError != nil {
+		return nil, closeError
+	}
+	if _, expressionError := createMatch(path, uint(patternId)); expressionError != nil {
+		return nil, p.errorAt(pathToken, nil, expressionError)
+	}
+	return CreateMatchNode(path, uint(patternId)), nil
+}
+
+func (p *ruleParser) parseCheck() (Node, error) {
+	path, pathToken, pathError := p.parsePath()
+	if pathError != nil {
+		return nil, pathError
+	}
+	operationToken := p.current
+	operation, operationError := p.parseOperation()
+	if operationError != nil {
+		return nil, operationError
+	}
+	argumentToken := p.current
+	argument, argumentError := p.parseOperationArgument(operation)
+	if argumentError != nil {
+		return nil, argumentError
+	}
+	if _, closeError := p.expect(tokenRightParen); closeError != nil {
+		return nil, closeError
+	}
+	node := CreateCheckNode(path, operation, argument)
+	if operation == OperationCustom {
+		node.Operator = operationToken.text
+	}
+	predicate, predicateError := checkPredicate(node, p.options.Operators)
+	if predicateError != nil {
+		return nil, p.errorAt(argumentToken, nil, predicateError)
+	}
+	if _, expressionError := createCheck(path, predicate); expressionError != nil {
+		return nil, p.errorAt(pathToken, nil, expressionError)
+	}
+	return node, nil
+}
+
+func (p *ruleParser) parsePath() (DataPath, token, error) {
+	pathToken, pathTokenError := p.expect(tokenWord, "path")
+	if pathTokenError != nil {
+		return DataPath{}, token{}, pathTokenError
+	}
+	path, pathError := ParseDataPath(pathToken.text)
+	if pathError != nil {
+		return DataPath{}, token{}, p.errorAt(pathToken, []string{"path"}, pathError)
+	}
+	return path, pathToken, nil
+}
+
+func (p *ruleParser) parseLiteral() (any, error) {
+	literalToken := p.current
+	var value any
+	var valueError error
+	switch literalToken.kind {
+	case tokenString:
+		value, valueError = literalToken.stringValue()
+	case tokenWord:
+		value, valueError = parseRuleWordLiteral(literalToken.text)
+	default:
+		return nil, p.errorAt(literalToken, literalNames, parseError)
+	}
+	if valueError != nil {
+		return nil, p.errorAt(literalToken, literalNames, valueError)
+	}
+	return value, p.advance()
+}
+
+func (p *ruleParser) parseOperation() (int, error) {
+	operationToken := p.current
+	if operationToken.kind != tokenOperator && operationToken.kind != tokenWord {
+		return 0, p.errorAt(operationToken, p.operationNames(), parseError)
+	}
+	if advanceError := p.advance(); advanceError != nil {
+		return 0, advanceError
+	}
+	if operationToken.kind == tokenWord && operationToken.text == "NOT" {
+		inToken, inError := p.expect(tokenWord, "IN")
+		if inError != nil {
+			return 0, inError
+		}
+		if inToken.text != "IN" {
+			return 0, p.errorAt(inToken, []string{"IN"}, unsupportedOperationError)
+		}
+		return OperationNotIn, nil
+	}
+	operation, exists := ruleOperations[operationToken.text]
+	if exists {
+		return operation, nil
+	}
+	if operationToken.kind == tokenWord && p.options.Operators.Has(operationToken.text) {
+		return OperationCustom, nil
+	}
+	return 0, p.errorAt(operationToken, p.operationNames(), unsupportedOperationError)
+}
+
+// operationNames returns names of built-in operations and custom operators
+func (p *ruleParser) operationNames() []string {
+	return append(append([]string{}, ruleOperationNames...), p.options.Operators.Names()...)
+}
+
+func (p *ruleParser) parseOperationArgument(operation int) (any, error) {
+	switch operation {
+	case OperationIn, OperationNotIn:
+		return p.parseLiteralList(false)
+	case OperationCustom:
+		return p.parseLiteralList(true)
+	case OperationBetween:
+		low, lowError := p.parseLiteral()
+		if lowError != nil {
+			return nil, lowError
+		}
+		andToken, andError := p.expect(tokenWord, "AND")
+		if andError != nil {
+			return nil, andError
+		}
+		if andToken.text != "AND" {
+			return nil, p.errorAt(andToken, []string{"AND"}, parseError)
+		}
+		high, highError := p.parseLiteral()
+		if highError != nil {
+			return nil, highError
+		}
+		return []any{low, high}, nil
+	default:
+		return p.parseLiteral()
+	}
+}
+
+// parseLiteralList parses (LITERAL,...), empty list () is allowed if allowEmpty is true
+func (p *ruleParser) parseLiteralList(allowEmpty bool) ([]any, error) {
+	if _, openError := p.expect(tokenLeftParen); openError != nil {
+		return nil, openError
+	}
+	items := make([]any, 0)
+	if allowEmpty && p.current.kind == tokenRightParen {
+		return items, p.advance()
+	}
+	for {
+		item, itemError := p.parseLiteral()
+		if itemError != nil {
+			return nil, itemError
+		}
+		items = append(items, item)
+		separator, separatorError := p.expectOneOf(tokenComma, tokenRightParen)
+		if separatorError != nil {
+			return nil, separatorError
+		}
+		if separator.kind == tokenRightParen {
+			return items, nil
+		}
+	}
+}
+
+func (p *ruleParser) expectOneOf(kinds ...tokenKind) (token, error) {
+	expected := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if p.current.kind == kind {
+			return p.expect(kind)
+		}
+		expected = append(expected, kind.String())
+	}
+	return token{}, p.errorAt(p.current, expected, parseError)
+}
+
+var ruleOperations = map[string]int{
+	"==":         OperationEqual,
+	"!=":         OperationNotEqual,
+	"<":          OperationLess,
+	"<=":         OperationLessOrEqual,
+	">":          OperationGreater,
+	">=":         OperationGreaterOrEqual,
+	"IN":         OperationIn,
+	"CONTAINS":   OperationContains,
+	"STARTSWITH": OperationStartsWith,
+	"ENDSWITH":   OperationEndsWith,
+	"BETWEEN":    OperationBetween,
+}
+
+// number literal is decimal, strconv.ParseFloat accepts also NaN, Inf and hexadecimal floats
+var numberLiteralExpression = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+func parseRuleWordLiteral(source string) (any, error) {
+	switch source {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if !numberLiteralExpression.MatchString(source) {
+		return nil, badLiteralError
+	}
+	if intValue, intError := strconv.Atoi(source); intError == nil {
+		return intValue, nil
+	}
+	// integers out of int range are parsed as float, overflow (e.g. 1e400) isn't finite number
+	if floatValue, floatError := strconv.ParseFloat(source, 64); floatError == nil {
+		return floatValue, nil
+	}
+	return nil, badLiteralError
+}
@@ -0,0 +1,311 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRule(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		expectedError error
+	}{
+		{
+			name:          "exists",
+			source:        "EXISTS(http.options.IDDQD)",
+			expectedError: nil,
+		},
+		{
+			name:          "match",
+			source:        "MATCH(http.options.IDDQD, 666)",
+			expectedError: nil,
+		},
+		{
+			name:          "check string",
+			source:        `CHECK(http.request.headers.X-Token == "abc")`,
+			expectedError: nil,
+		},
+		{
+			name:          "check escaped string",
+			source:        `CHECK(http.request.headers.X-Token != "a\"b\\c")`,
+			expectedError: nil,
+		},
+		{
+			name:          "comments",
+			source:        "AND( // first\n EXISTS(http.options.IDDQD), /* second */ CHECK(http.host == \"пример\"))",
+			expectedError: nil,
+		},
+		{
+			name:          "unterminated comment",
+			source:        "EXISTS(http.options.IDDQD) /* end",
+			expectedError: parseError,
+		},
+		{
+			name:          "check number",
+			source:        "CHECK(http.response.code == 200)",
+			expectedError: nil,
+		},
+		{
+			name:          "check float",
+			source:        "CHECK(http.client.geoip.lat != -12.5e1)",
+			expectedError: nil,
+		},
+		{
+			name:          "check bool",
+			source:        "CHECK(http.options.IDCLIP == true)",
+			expectedError: nil,
+		},
+		{
+			name:          "check null",
+			source:        "CHECK(http.options.IDCLIP == null)",
+			expectedError: nil,
+		},
+		{
+			name:          "logical",
+			source:        `AND(EXISTS(http.options.IDDQD), CHECK(http.request.headers.X-Token == "abc"))`,
+			expectedError: nil,
+		},
+		{
+			name: "nested logical",
+			source: "OR(AND(EXISTS(http.options.IDDQD),NOT(MATCH(http.options.IDDQD,666)))," +
+				"\n  AND(NOT(EXISTS(http.request.headers.IDKFA)), MATCH(http.request.headers.IDKFA, 777)))",
+			expectedError: nil,
+		},
+		{
+			name:          "quoted content path",
+			source:        `EXISTS(http.request.headers."X.Forwarded.For")`,
+			expectedError: nil,
+		},
+		{
+			name:          "content path for main path without content",
+			source:        "CHECK(http.response.code.value == 200)",
+			expectedError: badContentPathError,
+		},
+		{
+			name:          "non-ascii",
+			source:        `CHECK(http.client.geoip.city == "Москва")`,
+			expectedError: nil,
+		},
+		{
+			name:          "unknown main path",
+			source:        "EXISTS(https.options.IDDQD)",
+			expectedError: unknownMainPathError,
+		},
+		{
+			name:          "exists without content path",
+			source:        "EXISTS(http.request.time)",
+			expectedError: unknownMainPathError,
+		},
+		{
+			name:          "empty path part",
+			source:        "EXISTS(http..options)",
+			expectedError: badPathError,
+		},
+		{
+			name:          "unknown expression",
+			source:        "XOR(EXISTS(http.options.IDDQD),EXISTS(http.options.IDKFA))",
+			expectedError: unknownExpressionError,
+		},
+		{
+			name:          "ordering",
+			source:        "AND(CHECK(http.response.code > 499), CHECK(http.request.length >= 1e6))",
+			expectedError: nil,
+		},
+		{
+			name:          "in",
+			source:        `CHECK(http.request.method IN ("GET", "HEAD"))`,
+			expectedError: nil,
+		},
+		{
+			name:          "not in",
+			source:        "CHECK(http.response.code NOT IN (200, 204, null))",
+			expectedError: nil,
+		},
+		{
+			name:          "string operations",
+			source:        `OR(CHECK(http.request.path CONTAINS "admin"), CHECK(http.request.path STARTSWITH "/api"), CHECK(http.host ENDSWITH ".local"))`,
+			expectedError: nil,
+		},
+		{
+			name:          "between",
+			source:        "CHECK(http.client.geoip.lat BETWEEN -10.5 AND 10.5)",
+			expectedError: nil,
+		},
+		{
+			name:          "unsupported operation",
+			source:        `CHECK(http.options.IDDQD LIKE "IDCLIP")`,
+			expectedError: unsupportedOperationError,
+		},
+		{
+			name:          "ordering with bool",
+			source:        "CHECK(http.options.IDDQD < true)",
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          "in with mixed types",
+			source:        `CHECK(http.response.code IN (200, "204"))`,
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          "between with mixed types",
+			source:        `CHECK(http.response.code BETWEEN 200 AND "300")`,
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          "contains with number",
+			source:        "CHECK(http.request.path CONTAINS 1)",
+			expectedError: badArgumentTypeError,
+		},
+		{
+			name:          "bad list",
+			source:        "CHECK(http.response.code IN 200)",
+			expectedError: parseError,
+		},
+		{
+			name:          "bad literal",
+			source:        "CHECK(http.options.IDDQD == IDCLIP)",
+			expectedError: badLiteralError,
+		},
+		{
+			name:          "nan literal",
+			source:        "CHECK(http.request.method == NaN)",
+			expectedError: badLiteralError,
+		},
+		{
+			name:          "infinity literal",
+			source:        "CHECK(http.request.length BETWEEN -Inf AND 1)",
+			expectedError: badLiteralError,
+		},
+		{
+			name:          "hexadecimal literal",
+			source:        "CHECK(http.request.length < 0x1p4)",
+			expectedError: badLiteralError,
+		},
+		{
+			name:          "overflowing literal",
+			source:        "CHECK(http.request.length < 1e400)",
+			expectedError: badLiteralError,
+		},
+		{
+			name:          "unterminated string",
+			source:        `CHECK(http.options.IDDQD == "IDCLIP)`,
+			expectedError: parseError,
+		},
+		{
+			name:          "bad pattern",
+			source:        "MATCH(http.options.IDDQD, abc)",
+			expectedError: badArgsError,
+		},
+		{
+			name:          "single logical argument",
+			source:        "AND(EXISTS(http.options.IDDQD))",
+			expectedError: badArgsError,
+		},
+		{
+			name:          "trailing data",
+			source:        "EXISTS(http.options.IDDQD) EXISTS(http.options.IDDQD)",
+			expectedError: parseError,
+		},
+		{
+			name:          "unclosed",
+			source:        "NOT(EXISTS(http.options.IDDQD)",
+			expectedError: parseError,
+		},
+	}
+	for _, testCase := range testCases {
+		currentTestCase := testCase
+		t.Run(currentTestCase.name, func(t *testing.T) {
+			result, actualError := ParseRule(currentTestCase.source)
+			if currentTestCase.expectedError == nil {
+				assert.NotNil(t, result)
+				assert.NoError(t, actualError)
+			} else {
+				assert.Nil(t, result)
+				assert.ErrorIs(t, actualError, currentTestCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseExecuteRule(t *testing.T) {
+	httpData := &HttpData{}
+	source := `AND(EXISTS(http.options.IDDQD), CHECK(http.request.headers.X-Token == "abc"), MATCH(http.request.paths.1, 7))`
+	expression, expressionError := ParseRule(source)
+	assert.NoError(t, expressionError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	gomock.InOrder(
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "IDDQD", httpData).Return(true, nil),
+		executionManager.EXPECT().
+			RecursiveCheckRequestHeaderValue(gomock.Any(), newPredicateMatcher(), CreateSimpleContentPath("X-Token"), httpData).
+			Return(true, nil),
+		executionManager.EXPECT().
+			MatchRequestPathsElement(gomock.Any(), uint(7), 1, CreateSimpleContentPath("1"), httpData).
+			Return(true, nil),
+	)
+	actualResult, actualError := expression(context.Background(), httpData, executionManager)
+	assert.True(t, actualResult)
+	assert.NoError(t, actualError)
+}
+
+func TestParseExecuteQuotedPath(t *testing.T) {
+	httpData := &HttpData{}
+	source := `AND(EXISTS(http.options."a.b"), MATCH(http.options."a b", 7), CHECK(http.options."a.b" == 1), EXISTS(http.request.headers."X.Token"))`
+	expression, expressionError := ParseRule(source)
+	assert.NoError(t, expressionError)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	gomock.InOrder(
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "a.b", httpData).Return(true, nil),
+		executionManager.EXPECT().MatchOption(gomock.Any(), uint(7), "a b", httpData).Return(true, nil),
+		executionManager.EXPECT().CheckOption(gomock.Any(), newPredicateMatcher(), "a.b", httpData).Return(true, nil),
+		executionManager.EXPECT().
+			CheckRequestHeaderValueExistence(gomock.Any(), CreateSimpleContentPath("X.Token"), httpData).
+			Return(true, nil),
+	)
+	actualResult, actualError := expression(context.Background(), httpData, executionManager)
+	assert.True(t, actualResult)
+	assert.NoError(t, actualError)
+}
+
+// generateRuleSource returns OR of ANDs with leafCount leaves, separated by whitespace and comments
+func generateRuleSource(leafCount int) string {
+	leaves := make([]string, leafCount)
+	for index := range leaves {
+		switch index % 3 {
+		case 0:
+			leaves[index] = fmt.Sprintf(`CHECK(http.request.headers.X-%d == "значение %d")`, index, index)
+		case 1:
+			leaves[index] = fmt.Sprintf("EXISTS(http.options.O%d)", index)
+		default:
+			leaves[index] = fmt.Sprintf("MATCH(http.request.body, %d)", index)
+		}
+	}
+	groups := make([]string, 0, leafCount/4)
+	for start := 0; start < leafCount; start += 4 {
+		groups = append(groups, "AND(\n  "+strings.Join(leaves[start:start+4], ", /* leaf */\n  ")+")")
+	}
+	return "OR( // generated\n" + strings.Join(groups, ",\n") + ")"
+}
+
+func BenchmarkParseRule(b *testing.B) {
+	for _, leafCount := range []int{100, 1000, 10000} {
+		source := generateRuleSource(leafCount)
+		b.Run(fmt.Sprintf("leaves=%d", leafCount), func(b *testing.B) {
+			b.SetBytes(int64(len(source)))
+			for iteration := 0; iteration < b.N; iteration++ {
+				if _, parseError := ParseRuleNode(source); parseError != nil {
+					b.Fatal(parseError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var duplicateRuleError = errors.New("duplicate rule")
+var emptyRuleIdError = errors.New("empty rule id")
+var unknownRuleSyntaxError = errors.New("unknown rule syntax")
+
+// RuleSyntax is language of rule source
+type RuleSyntax int
+
+const (
+	// RuleLanguageSyntax - human-readable rule language (see ParseRule)
+	RuleLanguageSyntax RuleSyntax = iota
+	// IndexedSyntax - legacy syntax, paths and arguments are indexes in shared paths and arguments of rule set
+	IndexedSyntax
+)
+
+type RuleDefinition struct {
+	Id string
+	// rules with greater priority are first in evaluation result
+	Priority int
+	Tags     []string
+	Syntax   RuleSyntax
+	Source   string
+}
+
+// Rule is compiled rule of RuleSet
+type Rule struct {
+	RuleDefinition
+	Node      Node
+	predicate PredicateWithError
+}
+
+// HasTag returns true if rule is tagged with tag
+func (r *Rule) HasTag(tag string) bool {
+	for _, ruleTag := range r.Tags {
+		if ruleTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleSet compiles many rules with shared parse storage (paths, arguments and patterns) and compile options.
+// Rules are ordered by priority (descending), rules with equal priority keep order of adding.
+// Add isn't safe for concurrent use, Evaluate is safe for concurrent use if manager is safe for concurrent use.
+type RuleSet struct {
+	storage *parseStorage
+	options CompileOptions
+	rules   []*Rule
+	ids     map[string]bool
+}
+
+// CreateRuleSet creates empty rule set, paths and arguments are used by rules with IndexedSyntax,
+// options.Patterns (if not nil) is used for validation of pattern ids of all rules, options.Timeout is deadline of each rule
+func CreateRuleSet(paths []DataPath, arguments []any, options CompileOptions) *RuleSet {
+	return &RuleSet{
+		storage: &parseStorage{knownPath: paths, checkArguments: arguments, patterns: options.Patterns},
+		options: options,
+		ids:     map[string]bool{},
+	}
+}
+
+// Add compiles rule and adds it to rule set, errors are wrapped with rule id
+func (s *RuleSet) Add(definition RuleDefinition) error {
+	if _, addError := s.add(definition); addError != nil {
+		if definition.Id == "" {
+			return addError
+		}
+		return fmt.Errorf("rule %q: %w", definition.Id, addError)
+	}
+	return nil
+}
+
+// add returns name of wrong field of definition with error
+func (s *RuleSet) add(definition RuleDefinition) (string, error) {
+	if definition.Id == "" {
+		return "id", emptyRuleIdError
+	}
+	if s.ids[definition.Id] {
+		return "id", duplicateRuleError
+	}
+	node, nodeError := s.parseNode(definition)
+	if errors.Is(nodeError, unknownRuleSyntaxError) {
+		return "syntax", nodeError
+	}
+	if nodeError != nil {
+		return "expression", nodeError
+	}
+	predicate, predicateError := CompileWithOptions(node, s.options)
+	if predicateError != nil {
+		return "expression", predicateError
+	}
+	if s.options.Metrics != nil {
+		predicate = s.options.Metrics.Instrument(definition.Id, predicate)
+	}
+	rule := &Rule{RuleDefinition: definition, Node: node, predicate: predicate}
+	// insert after rules with greater or equal priority
+	index := sort.Search(len(s.rules), func(index int) bool { return s.rules[index].Priority < definition.Priority })
+	s.rules = append(s.rules, nil)
+	copy(s.rules[index+1:], s.rules[index:])
+	s.rules[index] = rule
+	s.ids[definition.Id] = true
+	return "", nil
+}
+
+func (s *RuleSet) parseNode(definition RuleDefinition) (Node, error) {
+	switch definition.Syntax {
+	case RuleLanguageSyntax:
+		return ParseRuleNodeWithOptions(definition.Source, s.options)
+	case IndexedSyntax:
+		return parseExpressionTreeNode(definition.Source, s.storage)
+	default:
+		return nil, unknownRuleSyntaxError
+	}
+}
+
+// Patterns returns pattern registry of rule set (CompileOptions.Patterns), it may be nil
+func (s *RuleSet) Patterns() *PatternRegistry {
+	return s.options.Patterns
+}
+
+// Rules returns rules in evaluation order
+func (s *RuleSet) Rules() []*Rule {
+	return s.rules
+}
+
+// Evaluate evaluates all rules and returns matched rules in evaluation order.
+// Failed rules don't stop evaluation, their errors are joined (and wrapped with rule id) into returned error,
+// evaluation is stopped only if ctx is done. Equal leaves and subexpressions of rules are evaluated once,
+// evaluation cache is created if ctx has no cache.
+func (s *RuleSet) Evaluate(ctx context.Context, data *HttpData, manager IExecutionManager) ([]*Rule, error) {
+	if EvaluationCacheFromContext(ctx) == nil {
+		ctx = WithEvaluationCache(ctx, CreateEvaluationCache())
+	}
+	var matched []*Rule
+	var ruleErrors []error
+	for _, rule := range s.rules {
+		if contextError := evaluationContextError(ctx); contextError != nil {
+			return matched, contextError
+		}
+		result, err := rule.predicate(ctx, data, manager)
+		if err != nil {
+			ruleErrors = append(ruleErrors, fmt.Errorf("rule %q: %w", rule.Id, err))
+			continue
+		}
+		if result {
+			matched = append(matched, rule)
+		}
+	}
+	return matched, errors.Join(ruleErrors...)
+}
@@ -0,0 +1,184 @@
+package expressiontree
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// HttpDataSeq is iterator of records (it has the same form as iter.Seq[*HttpData]),
+// iteration is stopped when yield returns false
+type HttpDataSeq func(yield func(data *HttpData) bool)
+
+type BatchOptions struct {
+	// count of concurrent evaluations, runtime.GOMAXPROCS(0) by default
+	Workers int
+	// if true, results are sent in order of records, otherwise in order of completion
+	Ordered bool
+}
+
+// BatchResult is result of evaluation of one record, Index is position of record in input
+type BatchResult struct {
+	Index int
+	Data  *HttpData
+	Rules []*Rule
+	Err   error
+}
+
+// BatchSummary is aggregated output of batch evaluation, Hits are counts of records matched by rule (by rule id).
+// Only results sent to Batch.Results are counted, results dropped after cancel aren't.
+type BatchSummary struct {
+	Records int
+	// count of records with evaluation errors
+	Failed int
+	Hits   map[string]int
+}
+
+// Batch is running batch evaluation, see RuleSet.EvaluateBatch
+type Batch struct {
+	results chan BatchResult
+	done    chan struct{}
+	summary BatchSummary
+}
+
+type batchRecord struct {
+	index int
+	data  *HttpData
+}
+
+// ChannelRecords returns iterator of records received from channel until it is closed or ctx is done
+func ChannelRecords(ctx context.Context, records <-chan *HttpData) HttpDataSeq {
+	return func(yield func(data *HttpData) bool) {
+		for {
+			select {
+			case data, received := <-records:
+				if !received || !yield(data) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// EvaluateBatch evaluates records by rule set with workers. Records are read only when there is room
+// for their results: at most 2*Workers records are evaluated or wait to be received from Results,
+// so Results must be drained. When ctx is done, reading of records is stopped and results which aren't sent yet
+// are dropped, evaluation ends without waiting for records (an iterator blocked on idle source is left to stop
+// by itself, see ChannelRecords). Results is closed at the end of evaluation, then Summary is available.
+func (s *RuleSet) EvaluateBatch(ctx context.Context, records HttpDataSeq, manager IExecutionManager, options BatchOptions) *Batch {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	batch := &Batch{
+		results: make(chan BatchResult),
+		done:    make(chan struct{}),
+		summary: BatchSummary{Hits: map[string]int{}},
+	}
+	// slots of records which are read, but their results aren't sent
+	window := make(chan struct{}, 2*workers)
+	queue := make(chan batchRecord)
+	evaluated := make(chan BatchResult, workers)
+	go func() {
+		defer close(queue)
+		index := 0
+		records(func(data *HttpData) bool {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+			select {
+			case queue <- batchRecord{index: index, data: data}:
+				index++
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	waitGroup := &sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for {
+				var record batchRecord
+				select {
+				case next, received := <-queue:
+					if !received {
+						return
+					}
+					record = next
+				case <-ctx.Done():
+					return
+				}
+				rules, err := s.Evaluate(ctx, record.data, manager)
+				evaluated <- BatchResult{Index: record.index, Data: record.data, Rules: rules, Err: err}
+			}
+		}()
+	}
+	go func() {
+		waitGroup.Wait()
+		close(evaluated)
+	}()
+	go batch.collect(ctx, evaluated, window, options.Ordered)
+	return batch
+}
+
+// collect sends evaluated results (in order of records if ordered is true) and aggregates summary
+func (b *Batch) collect(ctx context.Context, evaluated <-chan BatchResult, window <-chan struct{}, ordered bool) {
+	defer close(b.done)
+	defer close(b.results)
+	pending := map[int]BatchResult{}
+	next := 0
+	canceled := false
+	send := func(result BatchResult) {
+		defer func() { <-window }()
+		if canceled {
+			return
+		}
+		select {
+		case b.results <- result:
+		case <-ctx.Done():
+			canceled = true
+			return
+		}
+		b.summary.Records++
+		if result.Err != nil {
+			b.summary.Failed++
+		}
+		for _, rule := range result.Rules {
+			b.summary.Hits[rule.Id]++
+		}
+	}
+	for result := range evaluated {
+		if !ordered {
+			send(result)
+			continue
+		}
+		pending[result.Index] = result
+		for {
+			nextResult, exists := pending[next]
+			if !exists {
+				break
+			}
+			delete(pending, next)
+			next++
+			send(nextResult)
+		}
+	}
+}
+
+// Results returns channel of results, it is closed at the end of evaluation
+func (b *Batch) Results() <-chan BatchResult {
+	return b.results
+}
+
+// Summary waits for the end of evaluation and returns summary of sent results
+func (b *Batch) Summary() BatchSummary {
+	<-b.done
+	return b.summary
+}
@@ -0,0 +1,221 @@
+package expressiontree
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createBatchRuleSet creates rules "even" (option Even exists), "third" (option Third exists)
+// and "failing" (MATCH fails for options which aren't strings)
+func createBatchRuleSet(t *testing.T) *RuleSet {
+	t.Helper()
+	patterns := CreatePatternRegistry()
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 1, Kind: SubstringPattern, Value: "x"}))
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{Patterns: patterns})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "even", Source: "EXISTS(http.options.Even)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "third", Source: "EXISTS(http.options.Third)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "failing", Source: "MATCH(http.options.Value, 1)"}))
+	return ruleSet
+}
+
+func createBatchRecords(count int) []*HttpData {
+	records := make([]*HttpData, count)
+	for index := range records {
+		options := map[string]any{"Value": "x"}
+		if index%2 == 0 {
+			options["Even"] = true
+		}
+		if index%3 == 0 {
+			options["Third"] = true
+		}
+		if index%10 == 0 {
+			options["Value"] = struct{}{}
+		}
+		records[index] = &HttpData{Options: options}
+	}
+	return records
+}
+
+func sliceRecords(records []*HttpData) HttpDataSeq {
+	return func(yield func(data *HttpData) bool) {
+		for _, data := range records {
+			if !yield(data) {
+				return
+			}
+		}
+	}
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	ruleSet := createBatchRuleSet(t)
+	records := createBatchRecords(100)
+	for _, ordered := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ordered=%t", ordered), func(t *testing.T) {
+			batch := ruleSet.EvaluateBatch(context.Background(), sliceRecords(records), CreateExecutionManager(ruleSet.Patterns()),
+				BatchOptions{Workers: 4, Ordered: ordered})
+			var results []BatchResult
+			for result := range batch.Results() {
+				results = append(results, result)
+			}
+			assert.Len(t, results, len(records))
+			indexes := make([]int, 0, len(results))
+			for _, result := range results {
+				indexes = append(indexes, result.Index)
+			}
+			if ordered {
+				assert.True(t, sort.IntsAreSorted(indexes))
+			}
+			sort.Slice(results, func(left int, right int) bool { return results[left].Index < results[right].Index })
+			for index, result := range results {
+				assert.Equal(t, index, result.Index)
+				assert.Same(t, records[index], result.Data)
+				matchedIds := []string{}
+				for _, rule := range result.Rules {
+					matchedIds = append(matchedIds, rule.Id)
+				}
+				expectedIds := []string{}
+				if index%2 == 0 {
+					expectedIds = append(expectedIds, "even")
+				}
+				if index%3 == 0 {
+					expectedIds = append(expectedIds, "third")
+				}
+				if index%10 == 0 {
+					assert.ErrorIs(t, result.Err, coercionError)
+				} else {
+					assert.NoError(t, result.Err)
+					expectedIds = append(expectedIds, "failing")
+				}
+				assert.Equal(t, expectedIds, matchedIds)
+			}
+			assert.Equal(t, BatchSummary{Records: 100, Failed: 10, Hits: map[string]int{"even": 50, "third": 34, "failing": 90}},
+				batch.Summary())
+		})
+	}
+}
+
+func TestEvaluateBatchChannel(t *testing.T) {
+	ruleSet := createBatchRuleSet(t)
+	records := make(chan *HttpData)
+	go func() {
+		defer close(records)
+		for _, data := range createBatchRecords(30) {
+			records <- data
+		}
+	}()
+	batch := ruleSet.EvaluateBatch(context.Background(), ChannelRecords(context.Background(), records), CreateExecutionManager(ruleSet.Patterns()),
+		BatchOptions{Ordered: true})
+	next := 0
+	for result := range batch.Results() {
+		assert.Equal(t, next, result.Index)
+		next++
+	}
+	assert.Equal(t, 30, next)
+	assert.Equal(t, map[string]int{"even": 15, "third": 10, "failing": 27}, batch.Summary().Hits)
+}
+
+func TestEvaluateBatchBackpressure(t *testing.T) {
+	ruleSet := createBatchRuleSet(t)
+	var read atomic.Int32
+	records := createBatchRecords(100)
+	counted := func(yield func(data *HttpData) bool) {
+		for _, data := range records {
+			read.Add(1)
+			if !yield(data) {
+				return
+			}
+		}
+	}
+	batch := ruleSet.EvaluateBatch(context.Background(), counted, CreateExecutionManager(ruleSet.Patterns()),
+		BatchOptions{Workers: 2, Ordered: true})
+	time.Sleep(50 * time.Millisecond)
+	// results of window (2*workers records) aren't received, next record waits for room
+	assert.LessOrEqual(t, read.Load(), int32(2*2+1))
+	count := 0
+	for range batch.Results() {
+		count++
+	}
+	assert.Equal(t, 100, count)
+	assert.Equal(t, int32(100), read.Load())
+}
+
+func TestEvaluateBatchCancel(t *testing.T) {
+	ruleSet := createBatchRuleSet(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records := createBatchRecords(30)
+	infinite := func(yield func(data *HttpData) bool) {
+		for index := 0; ; index++ {
+			if !yield(records[index%len(records)]) {
+				return
+			}
+		}
+	}
+	batch := ruleSet.EvaluateBatch(ctx, infinite, CreateExecutionManager(ruleSet.Patterns()), BatchOptions{Workers: 3})
+	// summary counts only received results, results dropped after cancel aren't counted
+	expectedSummary := BatchSummary{Hits: map[string]int{}}
+	receive := func(result BatchResult) {
+		expectedSummary.Records++
+		if result.Err != nil {
+			expectedSummary.Failed++
+		}
+		for _, rule := range result.Rules {
+			expectedSummary.Hits[rule.Id]++
+		}
+	}
+	for range 10 {
+		receive(<-batch.Results())
+	}
+	cancel()
+	for result := range batch.Results() {
+		receive(result)
+	}
+	summary := batch.Summary()
+	assert.GreaterOrEqual(t, summary.Records, 10)
+	assert.Equal(t, expectedSummary, summary)
+}
+
+func TestEvaluateBatchCancelIdleSource(t *testing.T) {
+	ruleSet := createBatchRuleSet(t)
+	for _, name := range []string{"channel", "blocked iterator"} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			records := make(chan *HttpData)
+			released := make(chan struct{})
+			defer close(released)
+			source := ChannelRecords(ctx, records)
+			if name == "blocked iterator" {
+				// iterator ignores ctx and blocks after the first record
+				source = func(yield func(data *HttpData) bool) {
+					if yield(<-records) {
+						<-released
+					}
+				}
+			}
+			batch := ruleSet.EvaluateBatch(ctx, source, CreateExecutionManager(ruleSet.Patterns()), BatchOptions{Workers: 2})
+			records <- &HttpData{Options: map[string]any{"Even": true}}
+			result := <-batch.Results()
+			assert.Equal(t, 0, result.Index)
+			cancel()
+			summary := make(chan BatchSummary)
+			go func() {
+				for range batch.Results() {
+				}
+				summary <- batch.Summary()
+			}()
+			select {
+			case actual := <-summary:
+				assert.Equal(t, 1, actual.Records)
+			case <-time.After(time.Second):
+				t.Fatal("batch isn't finished after cancel")
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var emptyRuleSetError = errors.New("empty rule set")
+
+// RuleSetVersion is version of rule set held by RuleSetHolder, Manager is created for patterns of rule set
+type RuleSetVersion struct {
+	Id      uint64
+	RuleSet *RuleSet
+	Manager IExecutionManager
+}
+
+// RuleSetHolder holds active version of rule set, which can be swapped atomically while rule set is evaluated.
+// Evaluation uses version which is active at its start, so swap doesn't affect evaluations in progress.
+// Invalid new versions are rejected, active version is kept then. RuleSetHolder is safe for concurrent use.
+type RuleSetHolder struct {
+	active        atomic.Pointer[RuleSetVersion]
+	mutex         sync.Mutex
+	lastId        uint64
+	createManager func(patterns *PatternRegistry) IExecutionManager
+}
+
+// fileStamp identifies content of polled file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// CreateRuleSetHolder creates holder without active version, createManager creates manager for patterns
+// of each version (default execution manager is created if createManager is nil)
+func CreateRuleSetHolder(createManager func(patterns *PatternRegistry) IExecutionManager) *RuleSetHolder {
+	if createManager == nil {
+		createManager = func(patterns *PatternRegistry) IExecutionManager {
+			if patterns == nil {
+				return CreateExecutionManager(nil)
+			}
+			return CreateExecutionManager(patterns)
+		}
+	}
+	return &RuleSetHolder{createManager: createManager}
+}
+
+// Active returns active version or nil if there is no version yet
+func (h *RuleSetHolder) Active() *RuleSetVersion {
+	return h.active.Load()
+}
+
+// ActiveId returns id of active version, 0 if there is no version yet
+func (h *RuleSetHolder) ActiveId() uint64 {
+	if version := h.active.Load(); version != nil {
+		return version.Id
+	}
+	return 0
+}
+
+// Swap activates compiled rule set as new version, ids of versions increase from 1
+func (h *RuleSetHolder) Swap(ruleSet *RuleSet) (*RuleSetVersion, error) {
+	if ruleSet == nil {
+		return nil, emptyRuleSetError
+	}
+	manager := h.createManager(ruleSet.Patterns())
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.lastId++
+	version := &RuleSetVersion{Id: h.lastId, RuleSet: ruleSet, Manager: manager}
+	h.active.Store(version)
+	return version, nil
+}
+
+// Reload loads rule file (see LoadRuleFile) and activates it, active version is kept if file is invalid.
+// options.Patterns isn't changed by rule file (see CreateRuleSetFromFile), so options can be reused for next reloads.
+func (h *RuleSetHolder) Reload(filename string, options CompileOptions) (*RuleSetVersion, error) {
+	ruleSet, loadError := LoadRuleFile(filename, options)
+	if loadError != nil {
+		return nil, loadError
+	}
+	return h.Swap(ruleSet)
+}
+
+// Watch reloads rule file at start and then each time when its modification time or size is changed,
+// file is polled with interval until ctx is done. Result of each reload is passed to onReload (if it isn't nil).
+func (h *RuleSetHolder) Watch(ctx context.Context, filename string, options CompileOptions, interval time.Duration,
+	onReload func(version *RuleSetVersion, err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var loaded fileStamp
+	first := true
+	for {
+		info, statError := os.Stat(filename)
+		stamp := fileStamp{}
+		if statError == nil {
+			stamp = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+		// failed stat is reported once until file is changed
+		if first || stamp != loaded {
+			first = false
+			loaded = stamp
+			var version *RuleSetVersion
+			reloadError := statError
+			if reloadError == nil {
+				version, reloadError = h.Reload(filename, options)
+			}
+			if onReload != nil {
+				onReload(version, reloadError)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate evaluates active version of rule set (see RuleSet.Evaluate) with its manager
+func (h *RuleSetHolder) Evaluate(ctx context.Context, data *HttpData) (*RuleSetVersion, []*Rule, error) {
+	version := h.active.Load()
+	if version == nil {
+		return nil, nil, emptyRuleSetError
+	}
+	rules, err := version.RuleSet.Evaluate(ctx, data, version.Manager)
+	return version, rules, err
+}
@@ -0,0 +1,154 @@
+package expressiontree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingOptionManager blocks CheckOptionExistence until released
+type blockingOptionManager struct {
+	*ExecutionManager
+	started  chan struct{}
+	released chan struct{}
+}
+
+func (m *blockingOptionManager) CheckOptionExistence(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+	close(m.started)
+	<-m.released
+	return m.ExecutionManager.CheckOptionExistence(ctx, optionName, data)
+}
+
+func createHolderRuleSet(t *testing.T, definitions ...RuleDefinition) *RuleSet {
+	t.Helper()
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{})
+	for _, definition := range definitions {
+		assert.NoError(t, ruleSet.Add(definition))
+	}
+	return ruleSet
+}
+
+func matchedRuleIds(rules []*Rule) []string {
+	ids := []string{}
+	for _, rule := range rules {
+		ids = append(ids, rule.Id)
+	}
+	return ids
+}
+
+func TestRuleSetHolderSwap(t *testing.T) {
+	holder := CreateRuleSetHolder(nil)
+	assert.Nil(t, holder.Active())
+	assert.Zero(t, holder.ActiveId())
+	_, _, emptyError := holder.Evaluate(context.Background(), &HttpData{})
+	assert.ErrorIs(t, emptyError, emptyRuleSetError)
+	first, firstError := holder.Swap(createHolderRuleSet(t, RuleDefinition{Id: "a", Source: "TRUE()"}))
+	assert.NoError(t, firstError)
+	assert.Equal(t, uint64(1), first.Id)
+	_, nilError := holder.Swap(nil)
+	assert.ErrorIs(t, nilError, emptyRuleSetError)
+	assert.Same(t, first, holder.Active())
+	second, secondError := holder.Swap(createHolderRuleSet(t, RuleDefinition{Id: "b", Source: "TRUE()"}))
+	assert.NoError(t, secondError)
+	assert.Equal(t, uint64(2), holder.ActiveId())
+	version, rules, evaluateError := holder.Evaluate(context.Background(), &HttpData{})
+	assert.NoError(t, evaluateError)
+	assert.Same(t, second, version)
+	assert.Equal(t, []string{"b"}, matchedRuleIds(rules))
+}
+
+func TestRuleSetHolderKeepsInFlightVersion(t *testing.T) {
+	blocking := &blockingOptionManager{ExecutionManager: CreateExecutionManager(nil), started: make(chan struct{}), released: make(chan struct{})}
+	holder := CreateRuleSetHolder(func(patterns *PatternRegistry) IExecutionManager {
+		if blocking != nil {
+			manager := blocking
+			blocking = nil
+			return manager
+		}
+		return CreateExecutionManager(nil)
+	})
+	_, firstError := holder.Swap(createHolderRuleSet(t, RuleDefinition{Id: "old", Source: "EXISTS(http.options.A)"}))
+	assert.NoError(t, firstError)
+	manager := holder.Active().Manager.(*blockingOptionManager)
+	type evaluation struct {
+		version *RuleSetVersion
+		rules   []*Rule
+		err     error
+	}
+	evaluated := make(chan evaluation)
+	go func() {
+		version, rules, err := holder.Evaluate(context.Background(), &HttpData{Options: map[string]any{"A": "a"}})
+		evaluated <- evaluation{version: version, rules: rules, err: err}
+	}()
+	<-manager.started
+	_, secondError := holder.Swap(createHolderRuleSet(t, RuleDefinition{Id: "new", Source: "TRUE()"}))
+	assert.NoError(t, secondError)
+	close(manager.released)
+	result := <-evaluated
+	assert.NoError(t, result.err)
+	assert.Equal(t, uint64(1), result.version.Id)
+	assert.Equal(t, []string{"old"}, matchedRuleIds(result.rules))
+	assert.Equal(t, uint64(2), holder.ActiveId())
+}
+
+func TestRuleSetHolderReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "rules.json")
+	holder := CreateRuleSetHolder(nil)
+	// patterns of options are shared by versions, patterns of file are added to copy
+	options := CompileOptions{Patterns: CreatePatternRegistry()}
+	assert.NoError(t, options.Patterns.Add(PatternDefinition{Id: 2, Kind: ExactPattern, Value: "POST"}))
+	assert.NoError(t, os.WriteFile(filename, []byte(jsonRuleFile), 0o600))
+	first, firstError := holder.Reload(filename, options)
+	assert.NoError(t, firstError)
+	second, secondError := holder.Reload(filename, options)
+	assert.NoError(t, secondError)
+	assert.Equal(t, uint64(2), second.Id)
+	assert.NotSame(t, first.RuleSet.Patterns(), second.RuleSet.Patterns())
+	assert.False(t, options.Patterns.Has(1))
+	assert.NoError(t, os.WriteFile(filename, []byte(`{"rules": [{"id": "bad", "expression": "AND(TRUE()"}]}`), 0o600))
+	_, invalidError := holder.Reload(filename, options)
+	assert.ErrorIs(t, invalidError, parseError)
+	assert.Same(t, second, holder.Active())
+	version, rules, evaluateError := holder.Evaluate(context.Background(), createSampleHttpData())
+	assert.NoError(t, evaluateError)
+	assert.Same(t, second, version)
+	assert.Equal(t, []string{"god", "post", "bob", "port"}, matchedRuleIds(rules))
+}
+
+func TestRuleSetHolderWatch(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(filename, []byte("rules:\n  - {id: a, expression: TRUE()}\n"), 0o600))
+	holder := CreateRuleSetHolder(nil)
+	type reload struct {
+		version *RuleSetVersion
+		err     error
+	}
+	reloads := make(chan reload, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	watched := make(chan error)
+	go func() {
+		watched <- holder.Watch(ctx, filename, CompileOptions{}, 5*time.Millisecond, func(version *RuleSetVersion, err error) {
+			reloads <- reload{version: version, err: err}
+		})
+	}()
+	initial := <-reloads
+	assert.NoError(t, initial.err)
+	assert.Equal(t, uint64(1), initial.version.Id)
+	assert.NoError(t, os.WriteFile(filename, []byte("rules:\n  - {id: b, expression: TRUE()}\n  - {id: c, expression: FALSE()}\n"), 0o600))
+	changed := <-reloads
+	assert.NoError(t, changed.err)
+	assert.Equal(t, uint64(2), changed.version.Id)
+	assert.Equal(t, "b", changed.version.RuleSet.Rules()[0].Id)
+	assert.NoError(t, os.WriteFile(filename, []byte("rules: [{id: d, expression: XOR()}]\n"), 0o600))
+	invalid := <-reloads
+	assert.ErrorIs(t, invalid.err, unknownExpressionError)
+	assert.Nil(t, invalid.version)
+	assert.Equal(t, uint64(2), holder.ActiveId())
+	cancel()
+	assert.ErrorIs(t, <-watched, context.Canceled)
+	assert.Empty(t, reloads)
+}
@@ -0,0 +1,110 @@
+package expressiontree
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSet(t *testing.T) {
+	patterns := CreatePatternRegistry()
+	assert.NoError(t, patterns.Add(PatternDefinition{Id: 1, Kind: SubstringPattern, Value: "bob"}))
+	ruleSet := CreateRuleSet(
+		[]DataPath{CreateDataPathWithMainOnly(RequestMethodKey), CreateDataPathWithSimpleContent(OptionsKey, "IDDQD")},
+		[]any{"POST"},
+		CompileOptions{Patterns: patterns})
+	definitions := []RuleDefinition{
+		{Id: "post", Priority: 1, Tags: []string{"method"}, Syntax: IndexedSyntax, Source: "CHECK(0,0,0)"},
+		{Id: "get", Priority: 5, Tags: []string{"method"}, Source: `CHECK(http.request.method == "GET")`},
+		{Id: "bob", Priority: 5, Tags: []string{"body"}, Source: "MATCH(http.request.body, 1)"},
+		{Id: "god", Priority: 10, Syntax: IndexedSyntax, Source: "EXISTS(1)"},
+		{Id: "https", Priority: 1, Source: `CHECK(http.protocol == "https")`},
+	}
+	for _, definition := range definitions {
+		assert.NoError(t, ruleSet.Add(definition), definition.Id)
+	}
+	actualIds := []string{}
+	for _, rule := range ruleSet.Rules() {
+		actualIds = append(actualIds, rule.Id)
+	}
+	assert.Equal(t, []string{"god", "get", "bob", "post", "https"}, actualIds)
+	matched, evaluateError := ruleSet.Evaluate(context.Background(), createSampleHttpData(), CreateExecutionManager(patterns))
+	assert.NoError(t, evaluateError)
+	matchedIds := []string{}
+	for _, rule := range matched {
+		matchedIds = append(matchedIds, rule.Id)
+	}
+	assert.Equal(t, []string{"god", "bob", "post", "https"}, matchedIds)
+	assert.True(t, matched[1].HasTag("body"))
+	assert.False(t, matched[1].HasTag("method"))
+	assert.Equal(t, `CHECK(http.request.method == "POST")`, matched[2].Node.String())
+}
+
+func TestRuleSetAddError(t *testing.T) {
+	ruleSet := CreateRuleSet([]DataPath{CreateDataPathWithMainOnly(RequestMethodKey)}, []any{"POST"},
+		CompileOptions{Patterns: CreatePatternRegistry()})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "a", Source: "TRUE()"}))
+	testCases := []struct {
+		definition    RuleDefinition
+		expectedError error
+	}{
+		{definition: RuleDefinition{Id: "a", Source: "FALSE()"}, expectedError: duplicateRuleError},
+		{definition: RuleDefinition{Source: "FALSE()"}, expectedError: emptyRuleIdError},
+		{definition: RuleDefinition{Id: "b", Source: "AND(TRUE()"}, expectedError: parseError},
+		{definition: RuleDefinition{Id: "c", Source: "MATCH(http.host, 1)"}, expectedError: unknownPatternError},
+		{definition: RuleDefinition{Id: "d", Syntax: IndexedSyntax, Source: "CHECK(0,0,0"}, expectedError: parseError},
+		{definition: RuleDefinition{Id: "e", Syntax: RuleSyntax(7), Source: "TRUE()"}, expectedError: unknownRuleSyntaxError},
+	}
+	for _, testCase := range testCases {
+		actualError := ruleSet.Add(testCase.definition)
+		assert.ErrorIs(t, actualError, testCase.expectedError, testCase.definition.Source)
+		if testCase.definition.Id != "" {
+			assert.Contains(t, actualError.Error(), `rule "`+testCase.definition.Id+`"`)
+		}
+	}
+	assert.Len(t, ruleSet.Rules(), 1)
+}
+
+func TestRuleSetEvaluateError(t *testing.T) {
+	someError := errors.New("some error")
+	httpData := &HttpData{}
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "a", Source: "EXISTS(http.options.A)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "b", Source: "EXISTS(http.options.B)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "c", Source: "EXISTS(http.options.C)"}))
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	gomock.InOrder(
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).Return(true, nil),
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "B", httpData).Return(false, someError),
+		executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "C", httpData).Return(true, nil),
+	)
+	matched, evaluateError := ruleSet.Evaluate(context.Background(), httpData, executionManager)
+	assert.ErrorIs(t, evaluateError, someError)
+	assert.Equal(t, `rule "b": some error`, evaluateError.Error())
+	assert.Len(t, matched, 2)
+}
+
+func TestRuleSetEvaluateCancellation(t *testing.T) {
+	httpData := &HttpData{}
+	ruleSet := CreateRuleSet(nil, nil, CompileOptions{})
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "a", Source: "EXISTS(http.options.A)"}))
+	assert.NoError(t, ruleSet.Add(RuleDefinition{Id: "b", Source: "EXISTS(http.options.B)"}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	executionManager := NewMockIExecutionManager(mockController)
+	executionManager.EXPECT().CheckOptionExistence(gomock.Any(), "A", httpData).DoAndReturn(
+		func(ctx context.Context, optionName string, data *HttpData) (bool, error) {
+			cancel()
+			return true, nil
+		})
+	matched, evaluateError := ruleSet.Evaluate(ctx, httpData, executionManager)
+	assert.ErrorIs(t, evaluateError, context.Canceled)
+	assert.Len(t, matched, 1)
+}
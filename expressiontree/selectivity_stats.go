@@ -0,0 +1,66 @@
+package expressiontree
+
+import (
+	"context"
+	"sync"
+)
+
+// SelectivityStats collects results of leaves (by canonical source of leaf) for cost model,
+// it is filled by predicates compiled with CompileOptions.Stats and is safe for concurrent use
+type SelectivityStats struct {
+	mutex    sync.RWMutex
+	counters map[string]*selectivityCounter
+}
+
+type selectivityCounter struct {
+	trueCount  uint64
+	totalCount uint64
+}
+
+func CreateSelectivityStats() *SelectivityStats {
+	return &SelectivityStats{counters: map[string]*selectivityCounter{}}
+}
+
+// Record records result of leaf evaluation, failed evaluations aren't recorded
+func (s *SelectivityStats) Record(leaf Node, result bool) {
+	key := leaf.String()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	counter, exists := s.counters[key]
+	if !exists {
+		counter = &selectivityCounter{}
+		s.counters[key] = counter
+	}
+	counter.totalCount++
+	if result {
+		counter.trueCount++
+	}
+}
+
+// Selectivity returns estimate of probability that leaf is true (with Laplace smoothing),
+// ok == false if leaf wasn't evaluated
+func (s *SelectivityStats) Selectivity(leaf Node) (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	counter, exists := s.counters[leaf.String()]
+	if !exists || counter.totalCount == 0 {
+		return 0, false
+	}
+	return (float64(counter.trueCount) + 1) / (float64(counter.totalCount) + 2), true
+}
+
+func recordSelectivity(leaf Node, predicate PredicateWithError, stats *SelectivityStats) PredicateWithError {
+	if stats == nil {
+		return predicate
+	}
+	return func(ctx context.Context, data *HttpData, manager IExecutionManager) (bool, error) {
+		result, err := predicate(ctx, data, manager)
+		if err == nil {
+			stats.Record(leaf, result)
+		}
+		return result, err
+	}
+}